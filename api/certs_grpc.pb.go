@@ -25,16 +25,33 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CertsServiceClient interface {
 	AddTemplate(ctx context.Context, in *AddTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*Template, error)
 	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	ListTemplates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
 	DeleteCertificate(ctx context.Context, in *DeleteCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetCertificate(ctx context.Context, in *GetCertificateRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	GetCertificateMetadata(ctx context.Context, in *GetCertificateMetadataRequest, opts ...grpc.CallOption) (*Certificate, error)
 	TestTemplate(ctx context.Context, in *TestTemplateRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
 	UpdateCertificate(ctx context.Context, in *UpdateCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	AddCertificate(ctx context.Context, in *AddCertificateRequest, opts ...grpc.CallOption) (*AddCertificateResponse, error)
 	GetCertificateLink(ctx context.Context, in *GetCertificateLinkRequest, opts ...grpc.CallOption) (*GetCertificateLinkResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnrevokeCertificate(ctx context.Context, in *UnrevokeCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	VerifyCertificate(ctx context.Context, in *VerifyCertificateRequest, opts ...grpc.CallOption) (*VerifyCertificateResponse, error)
+	ListExpiringCertificates(ctx context.Context, in *ListExpiringCertificatesRequest, opts ...grpc.CallOption) (*ListExpiringCertificatesResponse, error)
+	RestoreTemplate(ctx context.Context, in *RestoreTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreCertificate(ctx context.Context, in *RestoreCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sends certificate file in chunks, the first one carries content type.
+	// Served over REST by plain HTTP handler supporting range requests instead of gateway.
+	DownloadCertificate(ctx context.Context, in *DownloadCertificateRequest, opts ...grpc.CallOption) (CertsService_DownloadCertificateClient, error)
+	// Open Badges 3.0 credential of certificate, JSON-LD verifiable credential signed by service
+	GetCertificateCredential(ctx context.Context, in *GetCertificateCredentialRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Profile of credentials issuer, listing keys verifying them
+	GetIssuerProfile(ctx context.Context, in *GetIssuerProfileRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Public keys verifying credentials as JSON Web Key Set
+	GetIssuerKeys(ctx context.Context, in *GetIssuerKeysRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
 }
 
 type certsServiceClient struct {
@@ -54,8 +71,8 @@ func (c *certsServiceClient) AddTemplate(ctx context.Context, in *AddTemplateReq
 	return out, nil
 }
 
-func (c *certsServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error) {
-	out := new(GetTemplateResponse)
+func (c *certsServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*Template, error) {
+	out := new(Template)
 	err := c.cc.Invoke(ctx, "/certs.CertsService/GetTemplate", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -72,7 +89,7 @@ func (c *certsServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTempl
 	return out, nil
 }
 
-func (c *certsServiceClient) ListTemplates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+func (c *certsServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
 	out := new(ListTemplatesResponse)
 	err := c.cc.Invoke(ctx, "/certs.CertsService/ListTemplates", in, out, opts...)
 	if err != nil {
@@ -108,6 +125,15 @@ func (c *certsServiceClient) GetCertificate(ctx context.Context, in *GetCertific
 	return out, nil
 }
 
+func (c *certsServiceClient) GetCertificateMetadata(ctx context.Context, in *GetCertificateMetadataRequest, opts ...grpc.CallOption) (*Certificate, error) {
+	out := new(Certificate)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/GetCertificateMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *certsServiceClient) TestTemplate(ctx context.Context, in *TestTemplateRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
 	out := new(httpbody.HttpBody)
 	err := c.cc.Invoke(ctx, "/certs.CertsService/TestTemplate", in, out, opts...)
@@ -144,21 +170,160 @@ func (c *certsServiceClient) GetCertificateLink(ctx context.Context, in *GetCert
 	return out, nil
 }
 
+func (c *certsServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/RevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) UnrevokeCertificate(ctx context.Context, in *UnrevokeCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/UnrevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) VerifyCertificate(ctx context.Context, in *VerifyCertificateRequest, opts ...grpc.CallOption) (*VerifyCertificateResponse, error) {
+	out := new(VerifyCertificateResponse)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/VerifyCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) ListExpiringCertificates(ctx context.Context, in *ListExpiringCertificatesRequest, opts ...grpc.CallOption) (*ListExpiringCertificatesResponse, error) {
+	out := new(ListExpiringCertificatesResponse)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/ListExpiringCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) RestoreTemplate(ctx context.Context, in *RestoreTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/RestoreTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) RestoreCertificate(ctx context.Context, in *RestoreCertificateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/RestoreCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) DownloadCertificate(ctx context.Context, in *DownloadCertificateRequest, opts ...grpc.CallOption) (CertsService_DownloadCertificateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CertsService_ServiceDesc.Streams[0], "/certs.CertsService/DownloadCertificate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &certsServiceDownloadCertificateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CertsService_DownloadCertificateClient interface {
+	Recv() (*httpbody.HttpBody, error)
+	grpc.ClientStream
+}
+
+type certsServiceDownloadCertificateClient struct {
+	grpc.ClientStream
+}
+
+func (x *certsServiceDownloadCertificateClient) Recv() (*httpbody.HttpBody, error) {
+	m := new(httpbody.HttpBody)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *certsServiceClient) GetCertificateCredential(ctx context.Context, in *GetCertificateCredentialRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/GetCertificateCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) GetIssuerProfile(ctx context.Context, in *GetIssuerProfileRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/GetIssuerProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) GetIssuerKeys(ctx context.Context, in *GetIssuerKeysRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/certs.CertsService/GetIssuerKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CertsServiceServer is the server API for CertsService service.
 // All implementations must embed UnimplementedCertsServiceServer
 // for forward compatibility
 type CertsServiceServer interface {
 	AddTemplate(context.Context, *AddTemplateRequest) (*emptypb.Empty, error)
-	GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error)
+	GetTemplate(context.Context, *GetTemplateRequest) (*Template, error)
 	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*emptypb.Empty, error)
-	ListTemplates(context.Context, *emptypb.Empty) (*ListTemplatesResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
 	DeleteCertificate(context.Context, *DeleteCertificateRequest) (*emptypb.Empty, error)
 	UpdateTemplate(context.Context, *UpdateTemplateRequest) (*emptypb.Empty, error)
 	GetCertificate(context.Context, *GetCertificateRequest) (*httpbody.HttpBody, error)
+	GetCertificateMetadata(context.Context, *GetCertificateMetadataRequest) (*Certificate, error)
 	TestTemplate(context.Context, *TestTemplateRequest) (*httpbody.HttpBody, error)
 	UpdateCertificate(context.Context, *UpdateCertificateRequest) (*emptypb.Empty, error)
 	AddCertificate(context.Context, *AddCertificateRequest) (*AddCertificateResponse, error)
 	GetCertificateLink(context.Context, *GetCertificateLinkRequest) (*GetCertificateLinkResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*emptypb.Empty, error)
+	UnrevokeCertificate(context.Context, *UnrevokeCertificateRequest) (*emptypb.Empty, error)
+	VerifyCertificate(context.Context, *VerifyCertificateRequest) (*VerifyCertificateResponse, error)
+	ListExpiringCertificates(context.Context, *ListExpiringCertificatesRequest) (*ListExpiringCertificatesResponse, error)
+	RestoreTemplate(context.Context, *RestoreTemplateRequest) (*emptypb.Empty, error)
+	RestoreCertificate(context.Context, *RestoreCertificateRequest) (*emptypb.Empty, error)
+	// Sends certificate file in chunks, the first one carries content type.
+	// Served over REST by plain HTTP handler supporting range requests instead of gateway.
+	DownloadCertificate(*DownloadCertificateRequest, CertsService_DownloadCertificateServer) error
+	// Open Badges 3.0 credential of certificate, JSON-LD verifiable credential signed by service
+	GetCertificateCredential(context.Context, *GetCertificateCredentialRequest) (*httpbody.HttpBody, error)
+	// Profile of credentials issuer, listing keys verifying them
+	GetIssuerProfile(context.Context, *GetIssuerProfileRequest) (*httpbody.HttpBody, error)
+	// Public keys verifying credentials as JSON Web Key Set
+	GetIssuerKeys(context.Context, *GetIssuerKeysRequest) (*httpbody.HttpBody, error)
 	mustEmbedUnimplementedCertsServiceServer()
 }
 
@@ -169,13 +334,13 @@ type UnimplementedCertsServiceServer struct {
 func (UnimplementedCertsServiceServer) AddTemplate(context.Context, *AddTemplateRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTemplate not implemented")
 }
-func (UnimplementedCertsServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error) {
+func (UnimplementedCertsServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*Template, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
 }
 func (UnimplementedCertsServiceServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
 }
-func (UnimplementedCertsServiceServer) ListTemplates(context.Context, *emptypb.Empty) (*ListTemplatesResponse, error) {
+func (UnimplementedCertsServiceServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
 }
 func (UnimplementedCertsServiceServer) DeleteCertificate(context.Context, *DeleteCertificateRequest) (*emptypb.Empty, error) {
@@ -187,6 +352,9 @@ func (UnimplementedCertsServiceServer) UpdateTemplate(context.Context, *UpdateTe
 func (UnimplementedCertsServiceServer) GetCertificate(context.Context, *GetCertificateRequest) (*httpbody.HttpBody, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCertificate not implemented")
 }
+func (UnimplementedCertsServiceServer) GetCertificateMetadata(context.Context, *GetCertificateMetadataRequest) (*Certificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateMetadata not implemented")
+}
 func (UnimplementedCertsServiceServer) TestTemplate(context.Context, *TestTemplateRequest) (*httpbody.HttpBody, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TestTemplate not implemented")
 }
@@ -199,6 +367,39 @@ func (UnimplementedCertsServiceServer) AddCertificate(context.Context, *AddCerti
 func (UnimplementedCertsServiceServer) GetCertificateLink(context.Context, *GetCertificateLinkRequest) (*GetCertificateLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateLink not implemented")
 }
+func (UnimplementedCertsServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedCertsServiceServer) RevokeCertificate(context.Context, *RevokeCertificateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificate not implemented")
+}
+func (UnimplementedCertsServiceServer) UnrevokeCertificate(context.Context, *UnrevokeCertificateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnrevokeCertificate not implemented")
+}
+func (UnimplementedCertsServiceServer) VerifyCertificate(context.Context, *VerifyCertificateRequest) (*VerifyCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyCertificate not implemented")
+}
+func (UnimplementedCertsServiceServer) ListExpiringCertificates(context.Context, *ListExpiringCertificatesRequest) (*ListExpiringCertificatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringCertificates not implemented")
+}
+func (UnimplementedCertsServiceServer) RestoreTemplate(context.Context, *RestoreTemplateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTemplate not implemented")
+}
+func (UnimplementedCertsServiceServer) RestoreCertificate(context.Context, *RestoreCertificateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCertificate not implemented")
+}
+func (UnimplementedCertsServiceServer) DownloadCertificate(*DownloadCertificateRequest, CertsService_DownloadCertificateServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadCertificate not implemented")
+}
+func (UnimplementedCertsServiceServer) GetCertificateCredential(context.Context, *GetCertificateCredentialRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateCredential not implemented")
+}
+func (UnimplementedCertsServiceServer) GetIssuerProfile(context.Context, *GetIssuerProfileRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIssuerProfile not implemented")
+}
+func (UnimplementedCertsServiceServer) GetIssuerKeys(context.Context, *GetIssuerKeysRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIssuerKeys not implemented")
+}
 func (UnimplementedCertsServiceServer) mustEmbedUnimplementedCertsServiceServer() {}
 
 // UnsafeCertsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -267,7 +468,7 @@ func _CertsService_DeleteTemplate_Handler(srv interface{}, ctx context.Context,
 }
 
 func _CertsService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ListTemplatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -279,7 +480,7 @@ func _CertsService_ListTemplates_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: "/certs.CertsService/ListTemplates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CertsServiceServer).ListTemplates(ctx, req.(*emptypb.Empty))
+		return srv.(CertsServiceServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -338,6 +539,24 @@ func _CertsService_GetCertificate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CertsService_GetCertificateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCertificateMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).GetCertificateMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/GetCertificateMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).GetCertificateMetadata(ctx, req.(*GetCertificateMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CertsService_TestTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TestTemplateRequest)
 	if err := dec(in); err != nil {
@@ -410,6 +629,207 @@ func _CertsService_GetCertificateLink_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CertsService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_RevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).RevokeCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/RevokeCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).RevokeCertificate(ctx, req.(*RevokeCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_UnrevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnrevokeCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).UnrevokeCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/UnrevokeCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).UnrevokeCertificate(ctx, req.(*UnrevokeCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_VerifyCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).VerifyCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/VerifyCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).VerifyCertificate(ctx, req.(*VerifyCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_ListExpiringCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).ListExpiringCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/ListExpiringCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).ListExpiringCertificates(ctx, req.(*ListExpiringCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_RestoreTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).RestoreTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/RestoreTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).RestoreTemplate(ctx, req.(*RestoreTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_RestoreCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).RestoreCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/RestoreCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).RestoreCertificate(ctx, req.(*RestoreCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_DownloadCertificate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadCertificateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CertsServiceServer).DownloadCertificate(m, &certsServiceDownloadCertificateServer{stream})
+}
+
+type CertsService_DownloadCertificateServer interface {
+	Send(*httpbody.HttpBody) error
+	grpc.ServerStream
+}
+
+type certsServiceDownloadCertificateServer struct {
+	grpc.ServerStream
+}
+
+func (x *certsServiceDownloadCertificateServer) Send(m *httpbody.HttpBody) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CertsService_GetCertificateCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCertificateCredentialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).GetCertificateCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/GetCertificateCredential",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).GetCertificateCredential(ctx, req.(*GetCertificateCredentialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_GetIssuerProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIssuerProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).GetIssuerProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/GetIssuerProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).GetIssuerProfile(ctx, req.(*GetIssuerProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_GetIssuerKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIssuerKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).GetIssuerKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certs.CertsService/GetIssuerKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).GetIssuerKeys(ctx, req.(*GetIssuerKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CertsService_ServiceDesc is the grpc.ServiceDesc for CertsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -445,6 +865,10 @@ var CertsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCertificate",
 			Handler:    _CertsService_GetCertificate_Handler,
 		},
+		{
+			MethodName: "GetCertificateMetadata",
+			Handler:    _CertsService_GetCertificateMetadata_Handler,
+		},
 		{
 			MethodName: "TestTemplate",
 			Handler:    _CertsService_TestTemplate_Handler,
@@ -461,7 +885,53 @@ var CertsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCertificateLink",
 			Handler:    _CertsService_GetCertificateLink_Handler,
 		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _CertsService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "RevokeCertificate",
+			Handler:    _CertsService_RevokeCertificate_Handler,
+		},
+		{
+			MethodName: "UnrevokeCertificate",
+			Handler:    _CertsService_UnrevokeCertificate_Handler,
+		},
+		{
+			MethodName: "VerifyCertificate",
+			Handler:    _CertsService_VerifyCertificate_Handler,
+		},
+		{
+			MethodName: "ListExpiringCertificates",
+			Handler:    _CertsService_ListExpiringCertificates_Handler,
+		},
+		{
+			MethodName: "RestoreTemplate",
+			Handler:    _CertsService_RestoreTemplate_Handler,
+		},
+		{
+			MethodName: "RestoreCertificate",
+			Handler:    _CertsService_RestoreCertificate_Handler,
+		},
+		{
+			MethodName: "GetCertificateCredential",
+			Handler:    _CertsService_GetCertificateCredential_Handler,
+		},
+		{
+			MethodName: "GetIssuerProfile",
+			Handler:    _CertsService_GetIssuerProfile_Handler,
+		},
+		{
+			MethodName: "GetIssuerKeys",
+			Handler:    _CertsService_GetIssuerKeys_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadCertificate",
+			Handler:       _CertsService_DownloadCertificate_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "certs.proto",
 }
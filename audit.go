@@ -0,0 +1,153 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Audited actions
+const (
+	AuditCreate   = "create"
+	AuditUpdate   = "update"
+	AuditDelete   = "delete"
+	AuditRevoke   = "revoke"
+	AuditUnrevoke = "unrevoke"
+	AuditRestore  = "restore"
+	AuditPurge    = "purge"
+)
+
+// Audited entities
+const (
+	AuditTemplate    = "template"
+	AuditCertificate = "certificate"
+)
+
+// Actor recorded when none was attached to context
+const AnonymousActor = "anonymous"
+
+// Single entry of append-only audit_event table.
+// Before and After hold JSON state of entity, nil for created and deleted entities respectively.
+type AuditEvent struct {
+	Id    int64
+	Time  time.Time
+	Actor string
+	// Whom actor claims to act for, not verified
+	OnBehalfOf string
+	Action     string
+	Entity     string
+	EntityId   string
+	Before     json.RawMessage
+	After      json.RawMessage
+}
+
+// Zero values are not used for filtering
+type AuditFilter struct {
+	// AuditTemplate or AuditCertificate, template primary keys and certificate ids may coincide
+	Entity   string
+	EntityId string
+	From     time.Time
+	To       time.Time
+}
+
+type actorKey struct{}
+
+type onBehalfOfKey struct{}
+
+// Returns copy of ctx carrying actor, which is recorded for every mutation made with it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// Returns copy of ctx carrying whom actor claims to act for, it is recorded next to actor and never replaces it
+func WithOnBehalfOf(ctx context.Context, onBehalfOf string) context.Context {
+	return context.WithValue(ctx, onBehalfOfKey{}, onBehalfOf)
+}
+
+// Empty when actor doesn't act for anyone
+func OnBehalfOfFromContext(ctx context.Context) string {
+	onBehalfOf, _ := ctx.Value(onBehalfOfKey{}).(string)
+	return onBehalfOf
+}
+
+// Writes audit event, should be called within the same transaction as audited mutation
+func audit(ctx context.Context, tx pgx.Tx, action, entity, entityId string, before, after any) error {
+	b, err := marshalState(before)
+	if err != nil {
+		return err
+	}
+	a, err := marshalState(after)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO audit_event (actor, action, entity, entity_id, before, after, on_behalf_of)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		ActorFromContext(ctx), action, entity, entityId, b, a, OnBehalfOfFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("unable to INSERT INTO audit_event: %w", err)
+	}
+	return nil
+}
+
+// Returns nil for nil state, so it stored as NULL
+func marshalState(state any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+	return b, nil
+}
+
+func (dr *DirectRegistry) ListAuditEvents(ctx context.Context, f AuditFilter) ([]AuditEvent, error) {
+	conds := []string{}
+	args := []any{}
+	if f.Entity != "" {
+		args = append(args, f.Entity)
+		conds = append(conds, fmt.Sprintf("entity=$%d", len(args)))
+	}
+	if f.EntityId != "" {
+		args = append(args, f.EntityId)
+		conds = append(conds, fmt.Sprintf("entity_id=$%d", len(args)))
+	}
+	if !f.From.IsZero() {
+		args = append(args, f.From)
+		conds = append(conds, fmt.Sprintf("created_at>=$%d", len(args)))
+	}
+	if !f.To.IsZero() {
+		args = append(args, f.To)
+		conds = append(conds, fmt.Sprintf("created_at<$%d", len(args)))
+	}
+	q := "SELECT id, created_at, actor, on_behalf_of, action, entity, entity_id, before, after FROM audit_event"
+	if len(conds) != 0 {
+		q += " WHERE " + strings.Join(conds, " AND ")
+	}
+	q += " ORDER BY id"
+
+	rows, err := dr.p.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to SELECT FROM audit_event: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (e AuditEvent, err error) {
+		err = row.Scan(&e.Id, &e.Time, &e.Actor, &e.OnBehalfOf, &e.Action, &e.Entity, &e.EntityId, &e.Before, &e.After)
+		return e, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert request into audit events list: %w", err)
+	}
+	return events, nil
+}
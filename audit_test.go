@@ -0,0 +1,138 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ActorFromContext(t *testing.T) {
+	t.Run("Actor attached to context", func(t *testing.T) {
+		ctx := WithActor(context.Background(), "admin")
+		assert.Equal(t, "admin", ActorFromContext(ctx))
+	})
+	t.Run("No actor attached to context", func(t *testing.T) {
+		assert.Equal(t, AnonymousActor, ActorFromContext(context.Background()))
+	})
+	t.Run("Empty actor attached to context", func(t *testing.T) {
+		ctx := WithActor(context.Background(), "")
+		assert.Equal(t, AnonymousActor, ActorFromContext(ctx))
+	})
+}
+
+func Test_OnBehalfOfFromContext(t *testing.T) {
+	ctx := WithOnBehalfOf(WithActor(context.Background(), "CN=admin"), "teacher")
+	assert.Equal(t, "teacher", OnBehalfOfFromContext(ctx))
+	assert.Equal(t, "CN=admin", ActorFromContext(ctx))
+	assert.Empty(t, OnBehalfOfFromContext(context.Background()))
+}
+
+func Test_marshalState(t *testing.T) {
+	t.Run("Nil state stored as NULL", func(t *testing.T) {
+		got, err := marshalState(nil)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("Template state", func(t *testing.T) {
+		got, err := marshalState(&templateSnapshot{"name", "content"})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name": "name", "content": "content"}`, string(got))
+	})
+}
+
+func Test_DirectRegistry_ListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "created_at", "actor", "on_behalf_of", "action", "entity", "entity_id", "before", "after"}
+	now := time.Now()
+	expEvents := []AuditEvent{
+		{1, now, "CN=admin", "", AuditCreate, AuditCertificate, "12345678", nil, json.RawMessage(`{"student": "A"}`)},
+		{2, now, "CN=admin", "teacher", AuditUpdate, AuditCertificate, "12345678", json.RawMessage(`{"student": "A"}`), json.RawMessage(`{"student": "B"}`)},
+	}
+
+	t.Run("Check listing all events (no filter)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		rows := pgxmock.NewRows(columns)
+		for _, e := range expEvents {
+			rows.AddRow(e.Id, e.Time, e.Actor, e.OnBehalfOf, e.Action, e.Entity, e.EntityId, []byte(e.Before), []byte(e.After))
+		}
+		mock.ExpectQuery(`FROM audit_event ORDER BY id`).WithArgs().WillReturnRows(rows)
+
+		got, err := dr.ListAuditEvents(ctx, AuditFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, got, len(expEvents))
+		for i := range expEvents {
+			assert.Equal(t, expEvents[i].Id, got[i].Id)
+			assert.Equal(t, expEvents[i].OnBehalfOf, got[i].OnBehalfOf)
+			assert.Equal(t, expEvents[i].Action, got[i].Action)
+			assert.Equal(t, string(expEvents[i].After), string(got[i].After))
+		}
+		assert.Empty(t, got[0].Before)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check listing events filtered by entity, its id and time range", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		from := now.Add(-time.Hour)
+		to := now.Add(time.Hour)
+		mock.ExpectQuery(`WHERE entity=\$1 AND entity_id=\$2 AND created_at>=\$3 AND created_at<\$4`).
+			WithArgs(AuditCertificate, "12345678", from, to).WillReturnRows(pgxmock.NewRows(columns))
+
+		got, err := dr.ListAuditEvents(ctx, AuditFilter{Entity: AuditCertificate, EntityId: "12345678", From: from, To: to})
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check listing events filtered by time range only", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectQuery(`WHERE created_at<\$1 ORDER BY id`).
+			WithArgs(now).WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err = dr.ListAuditEvents(ctx, AuditFilter{To: now})
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when it is unable to select from audit_event table", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectQuery("FROM audit_event").WillReturnError(fmt.Errorf("audit error"))
+
+		got, err := dr.ListAuditEvents(ctx, AuditFilter{})
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, "audit error")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
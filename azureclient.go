@@ -0,0 +1,137 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-pipeline-go/pipeline"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/c2fo/vfs/v6"
+	"github.com/c2fo/vfs/v6/backend/azure"
+	"github.com/c2fo/vfs/v6/utils"
+)
+
+// Scheme of azure backend in configuration, vfs itself identifies it by https scheme
+const AzureScheme = azure.Name
+
+// Options of azure backend. Endpoint replaces https://<account>.blob.core.windows.net,
+// e.g. http://127.0.0.1:10000/devstoreaccount1 for Azurite.
+type AzureOptions struct {
+	azure.Options
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Client of azure backend sending requests to custom endpoint, vfs one always uses public Azure cloud
+type azureEndpointClient struct {
+	pipeline pipeline.Pipeline
+	endpoint url.URL
+}
+
+var _ azure.Client = &azureEndpointClient{}
+
+func newAzureEndpointClient(opts *azure.Options, endpoint string) (*azureEndpointClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("endpoint must be absolute URL, got %q", endpoint)
+	}
+	credential, err := opts.Credential()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+	u.Path = utils.EnsureTrailingSlash(u.Path)
+	return &azureEndpointClient{azblob.NewPipeline(credential, azblob.PipelineOptions{}), *u}, nil
+}
+
+func (c *azureEndpointClient) containerURL(container string) azblob.ContainerURL {
+	u := c.endpoint
+	u.Path += container
+	return azblob.NewContainerURL(u, c.pipeline)
+}
+
+func (c *azureEndpointClient) blobURL(file vfs.File) azblob.BlockBlobURL {
+	return c.containerURL(file.Location().Volume()).NewBlockBlobURL(utils.RemoveLeadingSlash(file.Path()))
+}
+
+// Container is taken from path of locationURI, host of it is ignored
+func (c *azureEndpointClient) Properties(locationURI, filePath string) (*azure.BlobProperties, error) {
+	u, err := url.Parse(locationURI)
+	if err != nil {
+		return nil, err
+	}
+	containerURL := c.containerURL(strings.Trim(u.Path, "/"))
+	if filePath == "" {
+		_, err := containerURL.GetProperties(context.Background(), azblob.LeaseAccessConditions{})
+		return nil, err
+	}
+	resp, err := containerURL.NewBlockBlobURL(utils.RemoveLeadingSlash(filePath)).
+		GetProperties(context.Background(), azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return azure.NewBlobProperties(resp), nil
+}
+
+func (c *azureEndpointClient) SetMetadata(file vfs.File, metadata map[string]string) error {
+	_, err := c.blobURL(file).SetMetadata(context.Background(), metadata, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	return err
+}
+
+func (c *azureEndpointClient) Upload(file vfs.File, content io.ReadSeeker) error {
+	_, err := c.blobURL(file).Upload(context.Background(), content, azblob.BlobHTTPHeaders{}, azblob.Metadata{},
+		azblob.BlobAccessConditions{}, azblob.DefaultAccessTier, nil, azblob.ClientProvidedKeyOptions{}, azblob.ImmutabilityPolicyOptions{})
+	return err
+}
+
+func (c *azureEndpointClient) Download(file vfs.File) (io.ReadCloser, error) {
+	resp, err := c.blobURL(file).Download(context.Background(), 0, 0, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(azblob.RetryReaderOptions{}), nil
+}
+
+func (c *azureEndpointClient) Copy(srcFile vfs.File, tgtFile vfs.File) error {
+	resp, err := c.blobURL(tgtFile).StartCopyFromURL(context.Background(), c.blobURL(srcFile).URL(), azblob.Metadata{},
+		azblob.ModifiedAccessConditions{}, azblob.BlobAccessConditions{}, azblob.DefaultAccessTier, nil)
+	if err != nil {
+		return err
+	}
+	if status := resp.CopyStatus(); status != azblob.CopyStatusSuccess {
+		return fmt.Errorf("copy is %s", status)
+	}
+	return nil
+}
+
+// Lists blobs directly in location page by page, names include path of location
+func (c *azureEndpointClient) List(l vfs.Location) ([]string, error) {
+	containerURL := c.containerURL(l.Volume())
+	var names []string
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		resp, err := containerURL.ListBlobsHierarchySegment(context.Background(), marker, "/",
+			azblob.ListBlobsSegmentOptions{Prefix: utils.RemoveLeadingSlash(l.Path())})
+		if err != nil {
+			return nil, err
+		}
+		marker = resp.NextMarker
+		for _, b := range resp.Segment.BlobItems {
+			names = append(names, b.Name)
+		}
+	}
+	return names, nil
+}
+
+func (c *azureEndpointClient) Delete(file vfs.File) error {
+	_, err := c.blobURL(file).Delete(context.Background(), azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
+	return err
+}
+
+// Versioning isn't used by storage, so only current version is deleted
+func (c *azureEndpointClient) DeleteAllVersions(file vfs.File) error {
+	return c.Delete(file)
+}
@@ -1,6 +1,11 @@
 package golangunitedschoolcerts
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 type CachedRegistry struct {
 	r                   Registry
@@ -8,6 +13,10 @@ type CachedRegistry struct {
 	getTmplContentCache Cache[int, contentCached]
 	getCertificateCache Cache[string, certCached]
 	getListTmplCache    []string
+	// Ids of certificates which were not found, so guessing ids doesn't reach database
+	notFoundCache Cache[string, notFoundCached]
+	notFoundTTL   time.Duration
+	now           func() time.Time
 }
 
 type pkCached struct {
@@ -34,44 +43,59 @@ func (p certCached) Size() int {
 	return 1
 }
 
-func NewCachedRegistry(r Registry) (cr *CachedRegistry, err error) {
-	cr = &CachedRegistry{}
+type notFoundCached struct {
+	expiresAt time.Time
+}
+
+func (p notFoundCached) Size() int {
+	return 1
+}
+
+// Every cache holds up to capacity entries, zero means unlimited.
+// Certificates not found are remembered for notFoundTTL, zero disables it.
+func NewCachedRegistry(r Registry, capacity int, notFoundTTL time.Duration) (cr *CachedRegistry, err error) {
+	cr = &CachedRegistry{notFoundTTL: notFoundTTL, now: time.Now}
 	cr.r = r
-	if c, err := NewLRUCache[string, pkCached](0, nil); err != nil {
+	if c, err := NewLRUCache[string, pkCached](capacity, nil); err != nil {
 		return nil, fmt.Errorf("failed to create getTmplPkCache: %w", err)
 	} else {
 		cr.getTmplPkCache = NewSafeCache[string, pkCached](c)
 	}
-	if c, err := NewLRUCache[int, contentCached](0, nil); err != nil {
+	if c, err := NewLRUCache[int, contentCached](capacity, nil); err != nil {
 		return nil, fmt.Errorf("failed to create getTmplContentCache: %w", err)
 	} else {
 		cr.getTmplContentCache = NewSafeCache[int, contentCached](c)
 	}
-	if c, err := NewLRUCache[string, certCached](0, nil); err != nil {
+	if c, err := NewLRUCache[string, certCached](capacity, nil); err != nil {
 		return nil, fmt.Errorf("failed to create getCertificateCache: %w", err)
 	} else {
 		cr.getCertificateCache = NewSafeCache[string, certCached](c)
 	}
+	if c, err := NewLRUCache[string, notFoundCached](capacity, nil); err != nil {
+		return nil, fmt.Errorf("failed to create notFoundCache: %w", err)
+	} else {
+		cr.notFoundCache = NewSafeCache[string, notFoundCached](c)
+	}
 	return cr, nil
 }
 
-func (cr *CachedRegistry) GetTemplatePK(name string) (pk int, err error) {
+func (cr *CachedRegistry) GetTemplatePK(ctx context.Context, name string) (pk int, err error) {
 	if pc, ok := cr.getTmplPkCache.Get(name); ok {
 		return pc.pk, nil
 	}
-	if pk, err = cr.r.GetTemplatePK(name); err != nil {
+	if pk, err = cr.r.GetTemplatePK(ctx, name); err != nil {
 		return 0, err
 	}
 	cr.getTmplPkCache.Add(name, pkCached{pk})
 	return pk, nil
 }
 
-func (cr *CachedRegistry) GetTemplateContent(pk int) (content *string, err error) {
+func (cr *CachedRegistry) GetTemplateContent(ctx context.Context, pk int) (content *string, err error) {
 	cc, ok := cr.getTmplContentCache.Get(pk)
 	if ok {
 		return cc.content, nil
 	}
-	content, err = cr.r.GetTemplateContent(pk)
+	content, err = cr.r.GetTemplateContent(ctx, pk)
 	if err != nil {
 		return nil, err
 	}
@@ -79,12 +103,26 @@ func (cr *CachedRegistry) GetTemplateContent(pk int) (content *string, err error
 	return content, nil
 }
 
-func (cr *CachedRegistry) GetCertificate(id string) (cert *Certificate, err error) {
+// Not cached, certificate count changes with every added certificate
+func (cr *CachedRegistry) GetTemplate(ctx context.Context, pk int) (*Template, error) {
+	return cr.r.GetTemplate(ctx, pk)
+}
+
+func (cr *CachedRegistry) GetCertificate(ctx context.Context, id string) (cert *Certificate, err error) {
 	cc, ok := cr.getCertificateCache.Get(id)
 	if ok {
 		return cc.cert, nil
 	}
-	cert, err = cr.r.GetCertificate(id)
+	if nf, ok := cr.notFoundCache.Peek(id); ok {
+		if cr.now().Before(nf.expiresAt) {
+			return nil, ErrNotFound
+		}
+		cr.notFoundCache.Remove(id)
+	}
+	cert, err = cr.r.GetCertificate(ctx, id)
+	if errors.Is(err, ErrNotFound) && cr.notFoundTTL > 0 {
+		cr.notFoundCache.Add(id, notFoundCached{cr.now().Add(cr.notFoundTTL)})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -92,12 +130,16 @@ func (cr *CachedRegistry) GetCertificate(id string) (cert *Certificate, err erro
 	return cert, nil
 }
 
-func (cr *CachedRegistry) ListTemplates() (names []string, err error) {
+// Only list of live templates is cached
+func (cr *CachedRegistry) ListTemplates(ctx context.Context, withDeleted bool) (names []string, err error) {
+	if withDeleted {
+		return cr.r.ListTemplates(ctx, withDeleted)
+	}
 	lc := cr.getListTmplCache
 	if lc != nil {
 		return lc, nil
 	}
-	lc, err = cr.r.ListTemplates()
+	lc, err = cr.r.ListTemplates(ctx, withDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +147,8 @@ func (cr *CachedRegistry) ListTemplates() (names []string, err error) {
 	return lc, nil
 }
 
-func (cr *CachedRegistry) AddTemplate(name string, content string) (err error) {
-	err = cr.r.AddTemplate(name, content)
+func (cr *CachedRegistry) AddTemplate(ctx context.Context, name string, content string) (err error) {
+	err = cr.r.AddTemplate(ctx, name, content)
 	if err != nil {
 		return err
 	}
@@ -114,12 +156,12 @@ func (cr *CachedRegistry) AddTemplate(name string, content string) (err error) {
 	return nil
 }
 
-func (cr *CachedRegistry) CertificatesByTemplatePK(pk int) (ids []string, err error) {
-	return cr.r.CertificatesByTemplatePK(pk)
+func (cr *CachedRegistry) CertificatesByTemplatePK(ctx context.Context, pk int) (ids []string, err error) {
+	return cr.r.CertificatesByTemplatePK(ctx, pk)
 }
 
-func (cr *CachedRegistry) DeleteTemplate(pk int) (err error) {
-	err = cr.r.DeleteTemplate(pk)
+func (cr *CachedRegistry) DeleteTemplate(ctx context.Context, pk int) (err error) {
+	err = cr.r.DeleteTemplate(ctx, pk)
 	if err != nil {
 		return
 	}
@@ -136,55 +178,107 @@ func (cr *CachedRegistry) DeleteTemplate(pk int) (err error) {
 	return nil
 }
 
-func (cr *CachedRegistry) UpdateTemplate(pk int, m map[string]string) (err error) {
-	err = cr.r.UpdateTemplate(pk, m)
+func (cr *CachedRegistry) UpdateTemplate(ctx context.Context, pk int, u TemplateUpdate) (err error) {
+	err = cr.r.UpdateTemplate(ctx, pk, u)
 	if err != nil {
 		return
 	}
-	for k := range m {
-		switch k {
-		case "name", "Name":
-			names := cr.getTmplPkCache.Keys()
-			for _, name := range names {
-				tmp, ok := cr.getTmplPkCache.Peek(name)
-				if ok && pk == tmp.pk {
-					cr.getTmplPkCache.Remove(name)
-					break
-				}
+	if u.Name.Set {
+		names := cr.getTmplPkCache.Keys()
+		for _, name := range names {
+			tmp, ok := cr.getTmplPkCache.Peek(name)
+			if ok && pk == tmp.pk {
+				cr.getTmplPkCache.Remove(name)
+				break
 			}
-			cr.getListTmplCache = nil
-		case "content", "Content":
-			cr.getTmplContentCache.Remove(pk)
-			ids, err := cr.r.CertificatesByTemplatePK(pk)
-			if err != nil {
-				cr.getCertificateCache.Purge()
-				return err
-			} else {
-				for _, id := range ids {
-					cr.getCertificateCache.Remove(id)
-				}
+		}
+		cr.getListTmplCache = nil
+	}
+	if u.Content.Set {
+		cr.getTmplContentCache.Remove(pk)
+		ids, err := cr.r.CertificatesByTemplatePK(ctx, pk)
+		if err != nil {
+			cr.getCertificateCache.Purge()
+			return err
+		} else {
+			for _, id := range ids {
+				cr.getCertificateCache.Remove(id)
 			}
 		}
 	}
 	return
 }
 
-func (cr *CachedRegistry) DeleteCertificate(id string) error {
-	if err := cr.r.DeleteCertificate(id); err != nil {
+func (cr *CachedRegistry) DeleteCertificate(ctx context.Context, id string) error {
+	if err := cr.r.DeleteCertificate(ctx, id); err != nil {
 		return err
 	}
 	cr.getCertificateCache.Remove(id)
 	return nil
 }
 
-func (cr *CachedRegistry) AddCertificate(templateName, student, issueDate, course, mentors string) (*Certificate, error) {
-	return cr.r.AddCertificate(templateName, student, issueDate, course, mentors)
+func (cr *CachedRegistry) AddCertificate(ctx context.Context, templateName, student, issueDate, course, mentors string,
+	validFrom, validUntil *time.Time) (*Certificate, error) {
+	cert, err := cr.r.AddCertificate(ctx, templateName, student, issueDate, course, mentors, validFrom, validUntil)
+	if err != nil {
+		return nil, err
+	}
+	// Id could be guessed before it was issued
+	cr.notFoundCache.Remove(cert.Id)
+	return cert, nil
+}
+
+func (cr *CachedRegistry) ListExpiringCertificates(ctx context.Context, within time.Duration) ([]*Certificate, error) {
+	return cr.r.ListExpiringCertificates(ctx, within)
+}
+
+func (cr *CachedRegistry) UpdateCertificate(ctx context.Context, id string, u CertificateUpdate) (time.Time, error) {
+	timestamp, err := cr.r.UpdateCertificate(ctx, id, u)
+	if err != nil {
+		return timestamp, err
+	}
+	cr.getCertificateCache.Remove(id)
+	return timestamp, nil
+}
+
+func (cr *CachedRegistry) ListAuditEvents(ctx context.Context, f AuditFilter) ([]AuditEvent, error) {
+	return cr.r.ListAuditEvents(ctx, f)
+}
+
+func (cr *CachedRegistry) RevokeCertificate(ctx context.Context, id string, reason string) error {
+	if err := cr.r.RevokeCertificate(ctx, id, reason); err != nil {
+		return err
+	}
+	cr.getCertificateCache.Remove(id)
+	return nil
 }
 
-func (cr *CachedRegistry) UpdateCertificate(id string, m map[string]string) error {
-	if err := cr.r.UpdateCertificate(id, m); err != nil {
+func (cr *CachedRegistry) UnrevokeCertificate(ctx context.Context, id string) error {
+	if err := cr.r.UnrevokeCertificate(ctx, id); err != nil {
 		return err
 	}
 	cr.getCertificateCache.Remove(id)
 	return nil
 }
+
+func (cr *CachedRegistry) RestoreTemplate(ctx context.Context, name string) (err error) {
+	err = cr.r.RestoreTemplate(ctx, name)
+	if err != nil {
+		return err
+	}
+	cr.getListTmplCache = nil
+	return nil
+}
+
+// Deleted certificates are never cached, only lookups of them
+func (cr *CachedRegistry) RestoreCertificate(ctx context.Context, id string) error {
+	if err := cr.r.RestoreCertificate(ctx, id); err != nil {
+		return err
+	}
+	cr.notFoundCache.Remove(id)
+	return nil
+}
+
+func (cr *CachedRegistry) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	return cr.r.PurgeDeleted(ctx, before)
+}
@@ -1,6 +1,7 @@
 package golangunitedschoolcerts
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -13,7 +14,7 @@ var _ Registry = &CachedRegistry{}
 
 func createTestCachedRegistry(t *testing.T) (cr *CachedRegistry, rMock *MockRegistry) {
 	rMock = NewMockRegistry(t)
-	cr, err := NewCachedRegistry(rMock)
+	cr, err := NewCachedRegistry(rMock, 0, time.Minute)
 	if err != nil {
 		assert.FailNow(t, "unexpected error creating CachedRegistry: %w", err)
 	}
@@ -21,133 +22,219 @@ func createTestCachedRegistry(t *testing.T) (cr *CachedRegistry, rMock *MockRegi
 }
 
 func Test_CachedRegistry_GetTemplatePK(t *testing.T) {
+	ctx := context.Background()
 	name := "Test Name"
 	expPK := 1
 	t.Run("Cache hits after repetitive calls with same name", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		// Allow only single call to underlying Registry
-		rMock.EXPECT().GetTemplatePK(name).Return(expPK, nil).Once()
+		rMock.EXPECT().GetTemplatePK(ctx, name).Return(expPK, nil).Once()
 		for i := 0; i < 10; i++ {
-			got, err := cr.GetTemplatePK(name)
+			got, err := cr.GetTemplatePK(ctx, name)
 			assert.NoError(t, err)
 			assert.Equal(t, expPK, got)
 		}
 	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().GetTemplatePK(name).Return(0, fmt.Errorf("GetTemplatePK error"))
-		got, err := cr.GetTemplatePK(name)
+		rMock.EXPECT().GetTemplatePK(ctx, name).Return(0, fmt.Errorf("GetTemplatePK error"))
+		got, err := cr.GetTemplatePK(ctx, name)
 		assert.ErrorContains(t, err, "GetTemplatePK error")
 		assert.Zero(t, got)
 	})
 }
 
 func Test_CachedRegistry_GetTemplateContent(t *testing.T) {
+	ctx := context.Background()
 	pk := 1
 	expContent := " "
 	t.Run("Cache hits after repetitive calls with same name", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		// Allow only single call to underlying Registry
-		rMock.EXPECT().GetTemplateContent(pk).Return(&expContent, nil).Once()
+		rMock.EXPECT().GetTemplateContent(ctx, pk).Return(&expContent, nil).Once()
 		for i := 0; i < 10; i++ {
-			got, err := cr.GetTemplateContent(pk)
+			got, err := cr.GetTemplateContent(ctx, pk)
 			assert.NoError(t, err)
 			assert.Equal(t, expContent, *got)
 		}
 	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().GetTemplateContent(pk).Return(nil, fmt.Errorf("GetTemplateContent error"))
-		got, err := cr.GetTemplateContent(pk)
+		rMock.EXPECT().GetTemplateContent(ctx, pk).Return(nil, fmt.Errorf("GetTemplateContent error"))
+		got, err := cr.GetTemplateContent(ctx, pk)
 		assert.ErrorContains(t, err, "GetTemplateContent error")
 		assert.Nil(t, got)
 	})
 }
 
+func Test_CachedRegistry_GetTemplate(t *testing.T) {
+	ctx := context.Background()
+	pk := 1
+	exp := &Template{Pk: pk, Name: "name", CertificateCount: 1}
+	t.Run("Registry is called every time", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().GetTemplate(ctx, pk).Return(exp, nil).Twice()
+		for i := 0; i < 2; i++ {
+			got, err := cr.GetTemplate(ctx, pk)
+			assert.NoError(t, err)
+			assert.Equal(t, exp, got)
+		}
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().GetTemplate(ctx, pk).Return(nil, fmt.Errorf("GetTemplate error"))
+		got, err := cr.GetTemplate(ctx, pk)
+		assert.ErrorContains(t, err, "GetTemplate error")
+		assert.Nil(t, got)
+	})
+}
+
 func Test_CachedRegistry_GetCertificate(t *testing.T) {
+	ctx := context.Background()
 	id := " "
 	expCert := Certificate{Id: id}
 	t.Run("Cache hits after repetitive calls with same name", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		// Allow only single call to underlying Registry
-		rMock.EXPECT().GetCertificate(id).Return(&expCert, nil).Once()
+		rMock.EXPECT().GetCertificate(ctx, id).Return(&expCert, nil).Once()
 		for i := 0; i < 10; i++ {
-			got, err := cr.GetCertificate(id)
+			got, err := cr.GetCertificate(ctx, id)
 			assert.NoError(t, err)
 			assert.Equal(t, expCert, *got)
 		}
 	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().GetCertificate(id).Return(nil, fmt.Errorf("GetCertificate error"))
-		got, err := cr.GetCertificate(id)
-		assert.ErrorContains(t, err, "GetCertificate error")
-		assert.Nil(t, got)
+		rMock.EXPECT().GetCertificate(ctx, id).Return(nil, fmt.Errorf("GetCertificate error")).Twice()
+		for i := 0; i < 2; i++ {
+			got, err := cr.GetCertificate(ctx, id)
+			assert.ErrorContains(t, err, "GetCertificate error")
+			assert.Nil(t, got)
+		}
+	})
+	t.Run("Not found certificate is cached until TTL expires", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		now := time.Now()
+		cr.now = func() time.Time { return now }
+		rMock.EXPECT().GetCertificate(ctx, id).Return(nil, ErrNotFound).Once()
+		for i := 0; i < 10; i++ {
+			_, err := cr.GetCertificate(ctx, id)
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+
+		now = now.Add(time.Minute)
+		rMock.EXPECT().GetCertificate(ctx, id).Return(&expCert, nil).Once()
+		got, err := cr.GetCertificate(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, expCert, *got)
+	})
+	t.Run("Not found certificate is not cached with zero TTL", func(t *testing.T) {
+		rMock := NewMockRegistry(t)
+		cr, _ := NewCachedRegistry(rMock, 0, 0)
+		rMock.EXPECT().GetCertificate(ctx, id).Return(nil, ErrNotFound).Twice()
+		for i := 0; i < 2; i++ {
+			_, err := cr.GetCertificate(ctx, id)
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+	})
+	t.Run("Added and restored certificates are not found in cache", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().GetCertificate(ctx, id).Return(nil, ErrNotFound).Times(2)
+		rMock.EXPECT().GetCertificate(ctx, id).Return(&expCert, nil).Once()
+
+		_, err := cr.GetCertificate(ctx, id)
+		assert.ErrorIs(t, err, ErrNotFound)
+		rMock.EXPECT().RestoreCertificate(ctx, id).Return(nil)
+		assert.NoError(t, cr.RestoreCertificate(ctx, id))
+
+		_, err = cr.GetCertificate(ctx, id)
+		assert.ErrorIs(t, err, ErrNotFound)
+		rMock.EXPECT().AddCertificate(ctx, "Name", "", "", "", "", (*time.Time)(nil), (*time.Time)(nil)).Return(&expCert, nil)
+		_, err = cr.AddCertificate(ctx, "Name", "", "", "", "", nil, nil)
+		assert.NoError(t, err)
+
+		got, err := cr.GetCertificate(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, expCert, *got)
 	})
 }
 
 func Test_CachedRegistry_ListTemplates(t *testing.T) {
+	ctx := context.Background()
 	expNames := []string{" ", " ", " "}
 	t.Run("Cache hits after repetitive calls with same name", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		// Allow only single call to underlying Registry
-		rMock.EXPECT().ListTemplates().Return(expNames, nil).Once()
+		rMock.EXPECT().ListTemplates(ctx, false).Return(expNames, nil).Once()
 		for i := 0; i < 10; i++ {
-			got, err := cr.ListTemplates()
+			got, err := cr.ListTemplates(ctx, false)
 			assert.NoError(t, err)
 			assert.ElementsMatch(t, expNames, got)
 		}
 	})
+	t.Run("List with deleted templates is not cached", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().ListTemplates(ctx, true).Return(expNames, nil).Twice()
+		for i := 0; i < 2; i++ {
+			got, err := cr.ListTemplates(ctx, true)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, expNames, got)
+		}
+		assert.Nil(t, cr.getListTmplCache)
+	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().ListTemplates().Return(nil, fmt.Errorf("ListTemplates error"))
-		got, err := cr.ListTemplates()
+		rMock.EXPECT().ListTemplates(ctx, false).Return(nil, fmt.Errorf("ListTemplates error"))
+		got, err := cr.ListTemplates(ctx, false)
 		assert.ErrorContains(t, err, "ListTemplates error")
 		assert.Nil(t, got)
 	})
 }
 
 func Test_CachedRegistry_AddTemplate(t *testing.T) {
+	ctx := context.Background()
 	name := "name"
 	content := "content"
 	t.Run("Registry returns no error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().AddTemplate(name, content).Return(nil)
+		rMock.EXPECT().AddTemplate(ctx, name, content).Return(nil)
 		cr.getListTmplCache = append(cr.getListTmplCache, " ")
-		err := cr.AddTemplate(name, content)
+		err := cr.AddTemplate(ctx, name, content)
 		assert.NoError(t, err)
 		assert.Nil(t, cr.getListTmplCache)
 	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().AddTemplate(name, content).Return(fmt.Errorf("AddTemplate error"))
+		rMock.EXPECT().AddTemplate(ctx, name, content).Return(fmt.Errorf("AddTemplate error"))
 		cr.getListTmplCache = append(cr.getListTmplCache, " ")
-		err := cr.AddTemplate(name, content)
+		err := cr.AddTemplate(ctx, name, content)
 		assert.ErrorContains(t, err, "AddTemplate error")
 		assert.NotNil(t, cr.getListTmplCache)
 	})
 }
 
 func Test_CachedRegistry_CertificatesByTemplatePK(t *testing.T) {
+	ctx := context.Background()
 	pk := 1
 	expIds := []string{" ", " ", " "}
 	t.Run("Registry returns no error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().CertificatesByTemplatePK(pk).Return(expIds, nil)
-		got, err := cr.CertificatesByTemplatePK(pk)
+		rMock.EXPECT().CertificatesByTemplatePK(ctx, pk).Return(expIds, nil)
+		got, err := cr.CertificatesByTemplatePK(ctx, pk)
 		assert.NoError(t, err)
 		assert.Equal(t, expIds, got)
 	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().CertificatesByTemplatePK(pk).Return(nil, fmt.Errorf("CertificatesByTemplatePK error"))
-		got, err := cr.CertificatesByTemplatePK(pk)
+		rMock.EXPECT().CertificatesByTemplatePK(ctx, pk).Return(nil, fmt.Errorf("CertificatesByTemplatePK error"))
+		got, err := cr.CertificatesByTemplatePK(ctx, pk)
 		assert.ErrorContains(t, err, "CertificatesByTemplatePK error")
 		assert.Nil(t, got)
 	})
 }
 
 func Test_CachedRegistry_DeleteTemplate(t *testing.T) {
+	ctx := context.Background()
 	pk := 1
 	name := "1"
 	content := "content"
@@ -157,8 +244,8 @@ func Test_CachedRegistry_DeleteTemplate(t *testing.T) {
 		cr.getTmplPkCache.Add(name, pkCached{pk})
 		cr.getTmplContentCache.Add(pk, contentCached{&content})
 		cr.getListTmplCache = templates
-		rMock.EXPECT().DeleteTemplate(pk).Return(nil)
-		err := cr.DeleteTemplate(pk)
+		rMock.EXPECT().DeleteTemplate(ctx, pk).Return(nil)
+		err := cr.DeleteTemplate(ctx, pk)
 		assert.NoError(t, err)
 		ok := cr.getTmplPkCache.Contains(name)
 		assert.False(t, ok)
@@ -171,8 +258,8 @@ func Test_CachedRegistry_DeleteTemplate(t *testing.T) {
 		cr.getTmplPkCache.Add(name, pkCached{pk})
 		cr.getTmplContentCache.Add(pk, contentCached{&content})
 		cr.getListTmplCache = templates
-		rMock.EXPECT().DeleteTemplate(pk).Return(fmt.Errorf("DeleteTemplate error"))
-		err := cr.DeleteTemplate(pk)
+		rMock.EXPECT().DeleteTemplate(ctx, pk).Return(fmt.Errorf("DeleteTemplate error"))
+		err := cr.DeleteTemplate(ctx, pk)
 		assert.ErrorContains(t, err, "DeleteTemplate error")
 		ok := cr.getTmplPkCache.Contains(name)
 		assert.True(t, ok)
@@ -183,6 +270,7 @@ func Test_CachedRegistry_DeleteTemplate(t *testing.T) {
 }
 
 func Test_CachedRegistry_UpdateTemplate(t *testing.T) {
+	ctx := context.Background()
 	pk := 1
 	name := "1"
 	content := "content"
@@ -191,8 +279,8 @@ func Test_CachedRegistry_UpdateTemplate(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getTmplPkCache.Add(name, pkCached{pk})
 		cr.getListTmplCache = templates
-		rMock.EXPECT().UpdateTemplate(pk, map[string]string{"name": "new name"}).Return(nil)
-		err := cr.UpdateTemplate(pk, map[string]string{"name": "new name"})
+		rMock.EXPECT().UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name")}).Return(nil)
+		err := cr.UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name")})
 		assert.NoError(t, err)
 		ok := cr.getTmplPkCache.Contains(name)
 		assert.False(t, ok)
@@ -202,9 +290,9 @@ func Test_CachedRegistry_UpdateTemplate(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getTmplPkCache.Add(name, pkCached{pk})
 		cr.getListTmplCache = templates
-		rMock.EXPECT().UpdateTemplate(pk, map[string]string{"name": "new name"}).
+		rMock.EXPECT().UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name")}).
 			Return(fmt.Errorf("UpdateTemplate error"))
-		err := cr.UpdateTemplate(pk, map[string]string{"name": "new name"})
+		err := cr.UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name")})
 		assert.ErrorContains(t, err, "UpdateTemplate error")
 		ok := cr.getTmplPkCache.Contains(name)
 		assert.True(t, ok)
@@ -219,9 +307,9 @@ func Test_CachedRegistry_UpdateTemplate(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getTmplContentCache.Add(pk, contentCached{&content})
 		cr.getCertificateCache.Add(id, certCached{&cert})
-		rMock.EXPECT().UpdateTemplate(pk, map[string]string{"content": "new content"}).Return(nil)
-		rMock.EXPECT().CertificatesByTemplatePK(pk).Return(expIds, nil)
-		err := cr.UpdateTemplate(pk, map[string]string{"content": "new content"})
+		rMock.EXPECT().UpdateTemplate(ctx, pk, TemplateUpdate{Content: Set("new content")}).Return(nil)
+		rMock.EXPECT().CertificatesByTemplatePK(ctx, pk).Return(expIds, nil)
+		err := cr.UpdateTemplate(ctx, pk, TemplateUpdate{Content: Set("new content")})
 		assert.NoError(t, err)
 		ok := cr.getTmplContentCache.Contains(pk)
 		assert.False(t, ok)
@@ -234,9 +322,9 @@ func Test_CachedRegistry_UpdateTemplate(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getTmplContentCache.Add(pk, contentCached{&content})
 		cr.getCertificateCache.Add(id, certCached{&cert})
-		rMock.EXPECT().UpdateTemplate(pk, map[string]string{"content": "new content"}).Return(nil)
-		rMock.EXPECT().CertificatesByTemplatePK(pk).Return(nil, fmt.Errorf("CertificatesByTemplatePK error"))
-		err := cr.UpdateTemplate(pk, map[string]string{"content": "new content"})
+		rMock.EXPECT().UpdateTemplate(ctx, pk, TemplateUpdate{Content: Set("new content")}).Return(nil)
+		rMock.EXPECT().CertificatesByTemplatePK(ctx, pk).Return(nil, fmt.Errorf("CertificatesByTemplatePK error"))
+		err := cr.UpdateTemplate(ctx, pk, TemplateUpdate{Content: Set("new content")})
 		assert.ErrorContains(t, err, "CertificatesByTemplatePK error")
 		ok := cr.getTmplContentCache.Contains(pk)
 		assert.False(t, ok)
@@ -245,21 +333,22 @@ func Test_CachedRegistry_UpdateTemplate(t *testing.T) {
 
 	t.Run("Registry returns error (\"name\": \"new name\" + \"content\": \"new content\" + UpdateTemplate)", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().UpdateTemplate(pk, map[string]string{"name": "new name", "content": "new content"}).
+		rMock.EXPECT().UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name"), Content: Set("new content")}).
 			Return(fmt.Errorf("UpdateTemplate error"))
-		err := cr.UpdateTemplate(pk, map[string]string{"name": "new name", "content": "new content"})
+		err := cr.UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name"), Content: Set("new content")})
 		assert.ErrorContains(t, err, "UpdateTemplate error")
 	})
 }
 
 func Test_CachedRegistry_DeleteCertificate(t *testing.T) {
+	ctx := context.Background()
 	id := "1"
 	expCert := Certificate{Id: id}
 	t.Run("Registry returns no error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getCertificateCache.Add(id, certCached{&expCert})
-		rMock.EXPECT().DeleteCertificate(id).Return(nil)
-		err := cr.DeleteCertificate(id)
+		rMock.EXPECT().DeleteCertificate(ctx, id).Return(nil)
+		err := cr.DeleteCertificate(ctx, id)
 		assert.NoError(t, err)
 		got, ok := cr.getCertificateCache.Peek(id)
 		assert.False(t, ok)
@@ -268,8 +357,8 @@ func Test_CachedRegistry_DeleteCertificate(t *testing.T) {
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getCertificateCache.Add(id, certCached{&expCert})
-		rMock.EXPECT().DeleteCertificate(id).Return(fmt.Errorf("DeleteCertificate error"))
-		err := cr.DeleteCertificate(id)
+		rMock.EXPECT().DeleteCertificate(ctx, id).Return(fmt.Errorf("DeleteCertificate error"))
+		err := cr.DeleteCertificate(ctx, id)
 		assert.ErrorContains(t, err, "DeleteCertificate error")
 		got, ok := cr.getCertificateCache.Peek(id)
 		assert.True(t, ok)
@@ -278,6 +367,7 @@ func Test_CachedRegistry_DeleteCertificate(t *testing.T) {
 }
 
 func Test_CachedRegistry_AddCertificate(t *testing.T) {
+	ctx := context.Background()
 	var (
 		templateName = "test template"
 		id           = "1"
@@ -292,38 +382,41 @@ func Test_CachedRegistry_AddCertificate(t *testing.T) {
 		IssueDate: issueDate, Course: course, Mentors: mentors}
 	t.Run("Registry returns no error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().AddCertificate(templateName, student, issueDate, course, mentors).Return(&expCert, nil)
-		got, err := cr.AddCertificate(templateName, student, issueDate, course, mentors)
+		rMock.EXPECT().AddCertificate(ctx, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil)).Return(&expCert, nil)
+		got, err := cr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil))
 		assert.NoError(t, err)
 		assert.Equal(t, &expCert, got)
 	})
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
-		rMock.EXPECT().AddCertificate(templateName, student, issueDate, course, mentors).Return(nil, fmt.Errorf("AddCertificate error"))
-		got, err := cr.AddCertificate(templateName, student, issueDate, course, mentors)
+		rMock.EXPECT().AddCertificate(ctx, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil)).Return(nil, fmt.Errorf("AddCertificate error"))
+		got, err := cr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil))
 		assert.ErrorContains(t, err, "AddCertificate error")
 		assert.Nil(t, got)
 	})
 }
 
 func Test_CachedRegistry_UpdateCertificate(t *testing.T) {
+	ctx := context.Background()
 	var (
 		id      = "1"
 		expCert = Certificate{Id: id}
-		m       = map[string]string{
-			"template":   "test template",
-			"student":    "test student",
-			"issue_date": "test issue date",
-			"course":     "test course",
-			"mentors":    "test mentors",
+		u       = CertificateUpdate{
+			Template:  Set("test template"),
+			Student:   Set("test student"),
+			IssueDate: Set("test issue date"),
+			Course:    Set("test course"),
+			Mentors:   Set("test mentors"),
 		}
 	)
 	t.Run("Registry returns no error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getCertificateCache.Add(id, certCached{&expCert})
-		rMock.EXPECT().UpdateCertificate(id, m).Return(nil)
-		err := cr.UpdateCertificate(id, m)
+		timestamp := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+		rMock.EXPECT().UpdateCertificate(ctx, id, u).Return(timestamp, nil)
+		gotTimestamp, err := cr.UpdateCertificate(ctx, id, u)
 		assert.NoError(t, err)
+		assert.Equal(t, timestamp, gotTimestamp)
 		got, ok := cr.getCertificateCache.Peek(id)
 		assert.False(t, ok)
 		assert.Nil(t, got)
@@ -331,11 +424,150 @@ func Test_CachedRegistry_UpdateCertificate(t *testing.T) {
 	t.Run("Registry returns error", func(t *testing.T) {
 		cr, rMock := createTestCachedRegistry(t)
 		cr.getCertificateCache.Add(id, certCached{&expCert})
-		rMock.EXPECT().UpdateCertificate(id, m).Return(fmt.Errorf("AddCertificate error"))
-		err := cr.UpdateCertificate(id, m)
+		rMock.EXPECT().UpdateCertificate(ctx, id, u).Return(time.Time{}, fmt.Errorf("AddCertificate error"))
+		_, err := cr.UpdateCertificate(ctx, id, u)
 		assert.ErrorContains(t, err, "AddCertificate error")
 		got, ok := cr.getCertificateCache.Peek(id)
 		assert.Equal(t, got.cert, &expCert)
 		assert.True(t, ok)
 	})
 }
+
+func Test_CachedRegistry_ListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	f := AuditFilter{EntityId: "1"}
+	expEvents := []AuditEvent{{Id: 1, EntityId: "1"}}
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().ListAuditEvents(ctx, f).Return(expEvents, nil)
+		got, err := cr.ListAuditEvents(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, expEvents, got)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().ListAuditEvents(ctx, f).Return(nil, fmt.Errorf("ListAuditEvents error"))
+		got, err := cr.ListAuditEvents(ctx, f)
+		assert.ErrorContains(t, err, "ListAuditEvents error")
+		assert.Nil(t, got)
+	})
+}
+
+func Test_CachedRegistry_RevokeCertificate(t *testing.T) {
+	ctx := context.Background()
+	id := "1"
+	reason := "misconduct"
+	expCert := Certificate{Id: id}
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		cr.getCertificateCache.Add(id, certCached{&expCert})
+		rMock.EXPECT().RevokeCertificate(ctx, id, reason).Return(nil)
+		err := cr.RevokeCertificate(ctx, id, reason)
+		assert.NoError(t, err)
+		assert.False(t, cr.getCertificateCache.Contains(id))
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		cr.getCertificateCache.Add(id, certCached{&expCert})
+		rMock.EXPECT().RevokeCertificate(ctx, id, reason).Return(fmt.Errorf("RevokeCertificate error"))
+		err := cr.RevokeCertificate(ctx, id, reason)
+		assert.ErrorContains(t, err, "RevokeCertificate error")
+		assert.True(t, cr.getCertificateCache.Contains(id))
+	})
+}
+
+func Test_CachedRegistry_UnrevokeCertificate(t *testing.T) {
+	ctx := context.Background()
+	id := "1"
+	expCert := Certificate{Id: id}
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		cr.getCertificateCache.Add(id, certCached{&expCert})
+		rMock.EXPECT().UnrevokeCertificate(ctx, id).Return(nil)
+		err := cr.UnrevokeCertificate(ctx, id)
+		assert.NoError(t, err)
+		assert.False(t, cr.getCertificateCache.Contains(id))
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		cr.getCertificateCache.Add(id, certCached{&expCert})
+		rMock.EXPECT().UnrevokeCertificate(ctx, id).Return(fmt.Errorf("UnrevokeCertificate error"))
+		err := cr.UnrevokeCertificate(ctx, id)
+		assert.ErrorContains(t, err, "UnrevokeCertificate error")
+		assert.True(t, cr.getCertificateCache.Contains(id))
+	})
+}
+
+func Test_CachedRegistry_RestoreTemplate(t *testing.T) {
+	ctx := context.Background()
+	name := "name"
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().RestoreTemplate(ctx, name).Return(nil)
+		cr.getListTmplCache = append(cr.getListTmplCache, " ")
+		err := cr.RestoreTemplate(ctx, name)
+		assert.NoError(t, err)
+		assert.Nil(t, cr.getListTmplCache)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().RestoreTemplate(ctx, name).Return(fmt.Errorf("RestoreTemplate error"))
+		cr.getListTmplCache = append(cr.getListTmplCache, " ")
+		err := cr.RestoreTemplate(ctx, name)
+		assert.ErrorContains(t, err, "RestoreTemplate error")
+		assert.NotNil(t, cr.getListTmplCache)
+	})
+}
+
+func Test_CachedRegistry_RestoreCertificate(t *testing.T) {
+	ctx := context.Background()
+	id := "00000000"
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().RestoreCertificate(ctx, id).Return(nil)
+		assert.NoError(t, cr.RestoreCertificate(ctx, id))
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().RestoreCertificate(ctx, id).Return(fmt.Errorf("RestoreCertificate error"))
+		assert.ErrorContains(t, cr.RestoreCertificate(ctx, id), "RestoreCertificate error")
+	})
+}
+
+func Test_CachedRegistry_PurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().PurgeDeleted(ctx, before).Return(2, nil)
+		n, err := cr.PurgeDeleted(ctx, before)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().PurgeDeleted(ctx, before).Return(0, fmt.Errorf("PurgeDeleted error"))
+		_, err := cr.PurgeDeleted(ctx, before)
+		assert.ErrorContains(t, err, "PurgeDeleted error")
+	})
+}
+
+func Test_CachedRegistry_ListExpiringCertificates(t *testing.T) {
+	ctx := context.Background()
+	within := time.Hour
+	expCerts := []*Certificate{{Id: "1"}, {Id: "2"}}
+	t.Run("Registry returns no error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().ListExpiringCertificates(ctx, within).Return(expCerts, nil)
+		got, err := cr.ListExpiringCertificates(ctx, within)
+		assert.NoError(t, err)
+		assert.Equal(t, expCerts, got)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		cr, rMock := createTestCachedRegistry(t)
+		rMock.EXPECT().ListExpiringCertificates(ctx, within).Return(nil, fmt.Errorf("ListExpiringCertificates error"))
+		got, err := cr.ListExpiringCertificates(ctx, within)
+		assert.ErrorContains(t, err, "ListExpiringCertificates error")
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	crt "gitlab.com/DzmitryYafremenka/golang-united-school-certs"
+)
+
+const migrateUsage = "usage: certs migrate up|down|status"
+
+// Handles `certs migrate` subcommand:
+//   - up - applies pending migrations
+//   - down - reverts the latest applied migration
+//   - status - lists migrations with time they were applied at
+func runMigrate(connString string, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+	m, err := crt.NewMigrator(connString)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, a := range applied {
+			fmt.Printf("applied %04d_%s\n", a.Version, a.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+	case "down":
+		reverted, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %04d_%s\n", reverted.Version, reverted.Name)
+		return nil
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range status {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
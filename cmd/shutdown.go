@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Counts requests in flight, including gRPC calls served over hijacked h2c connections,
+// which http.Server.Shutdown doesn't wait for
+type inFlight struct {
+	n atomic.Int64
+}
+
+func (f *inFlight) handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.n.Add(1)
+		defer f.n.Add(-1)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Waits until no requests are in flight or ctx is done, returning number of requests left
+func (f *inFlight) wait(ctx context.Context) int64 {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		n := f.n.Load()
+		if n == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return n
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	vfsOs "github.com/c2fo/vfs/v6/backend/os"
+	crt "gitlab.com/DzmitryYafremenka/golang-united-school-certs"
+)
+
+// Creates storage over configured tiers
+func newStorage(c *crt.Config) (*crt.TieredStorage, error) {
+	var tiers []crt.StorageTier
+	for _, tc := range c.StorageTiers() {
+		s, err := newTierStorage(tc)
+		if err != nil {
+			return nil, fmt.Errorf("tier %s: %w", tc.Name, err)
+		}
+		tiers = append(tiers, crt.StorageTier{Name: tc.Name, Storage: s, PromoteOnRead: tc.PromoteOnRead, Write: tc.Write})
+	}
+	return crt.NewTieredStorage(tiers, c.Storage.WriteBackQueue)
+}
+
+func newTierStorage(c crt.StorageTierConfig) (crt.Storage, error) {
+	if c.Scheme == crt.MemoryTierScheme {
+		log.Printf("Storage tier %s keeping up to %d certificates in memory", c.Name, c.Capacity)
+		return crt.NewMemStorage(c.Capacity)
+	}
+	path := c.Path
+	if c.Scheme == vfsOs.Scheme {
+		var err error
+		if path, err = filepath.Abs(path); err != nil {
+			return nil, fmt.Errorf("failed to resolve absolute storage path: %w", err)
+		}
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	opts, err := c.VfsOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse storage options: %w", err)
+	}
+	log.Printf("Storage tier %s pointing to: %s://%s%s", c.Name, c.Scheme, c.Volume, path)
+	return crt.NewVfsStorage(c.Volume, path, c.Scheme, opts, c.Capacity)
+}
@@ -0,0 +1,465 @@
+package golangunitedschoolcerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JSON-LD contexts of Open Badges 3.0 credentials
+const (
+	credentialsContext = "https://www.w3.org/ns/credentials/v2"
+	openBadgesContext  = "https://purl.imsglobal.org/spec/ob/v3p0/context-3.0.3.json"
+	multikeyContext    = "https://w3id.org/security/multikey/v1"
+)
+
+// Image of certificate in credentials, PNG is understood by badge wallets
+var credentialImage = Variant{Format: FormatPNG, Width: 640}
+
+// Ed25519 key signing credentials with eddsa-jcs-2022 Data Integrity proofs, see NewCredentialSigner
+type CredentialSigner struct {
+	key ed25519.PrivateKey
+	// Keys verifying credentials, the signing one first
+	keys []ed25519.PublicKey
+	now  func() time.Time
+}
+
+// Loads signing key from PKCS #8 PEM file. Retired keys are PKIX PEM public keys,
+// still published after rotation so credentials signed with them stay verifiable.
+func NewCredentialSigner(keyFile string, retiredKeyFiles []string) (*CredentialSigner, error) {
+	block, err := readPEM(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %s: %w", keyFile, err)
+	}
+	signing, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key %s is %T, must be Ed25519", keyFile, key)
+	}
+	var retired []ed25519.PublicKey
+	for _, f := range retiredKeyFiles {
+		block, err := readPEM(f)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %s: %w", f, err)
+		}
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key %s is %T, must be Ed25519", f, key)
+		}
+		retired = append(retired, pub)
+	}
+	return newCredentialSigner(signing, retired...), nil
+}
+
+func newCredentialSigner(key ed25519.PrivateKey, retired ...ed25519.PublicKey) *CredentialSigner {
+	keys := append([]ed25519.PublicKey{key.Public().(ed25519.PublicKey)}, retired...)
+	return &CredentialSigner{key: key, keys: keys, now: time.Now}
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+// Adds eddsa-jcs-2022 proof to document: Ed25519 signature over SHA-256 hashes of JCS canonical proof configuration and document
+func (c *CredentialSigner) sign(doc map[string]any, verificationMethod string) error {
+	proof := map[string]any{
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        "eddsa-jcs-2022",
+		"created":            c.now().UTC().Format(time.RFC3339),
+		"verificationMethod": verificationMethod,
+		"proofPurpose":       "assertionMethod",
+	}
+	if ctx, ok := doc["@context"]; ok {
+		proof["@context"] = ctx
+	}
+	hash, err := proofHash(doc, proof)
+	if err != nil {
+		return err
+	}
+	proof["proofValue"] = "z" + base58(ed25519.Sign(c.key, hash))
+	doc["proof"] = proof
+	return nil
+}
+
+// Data signed by eddsa-jcs-2022 proof
+func proofHash(doc map[string]any, proof map[string]any) ([]byte, error) {
+	canonicalProof, err := canonicalJSON(proof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize proof: %w", err)
+	}
+	canonicalDoc, err := canonicalJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize document: %w", err)
+	}
+	proofHash, docHash := sha256.Sum256(canonicalProof), sha256.Sum256(canonicalDoc)
+	return append(proofHash[:], docHash[:]...), nil
+}
+
+// RFC 7638 thumbprint of key, used as its id
+func keyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256([]byte(`{"crv":"Ed25519","kty":"OKP","x":"` + base64.RawURLEncoding.EncodeToString(pub) + `"}`))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Multibase of key prefixed by ed25519-pub multicodec
+func multikey(pub ed25519.PublicKey) string {
+	return "z" + base58(append([]byte{0xed, 0x01}, pub...))
+}
+
+// JSON Canonicalization Scheme, RFC 8785. Only integer numbers are supported, credentials don't have others.
+func canonicalJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var doc any
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := writeCanonical(buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil || n > 1<<53 || n < -(1<<53) {
+			return fmt.Errorf("number %s isn't safe integer", v)
+		}
+		buf.WriteString(strconv.FormatInt(n, 10))
+	case string:
+		writeCanonicalString(buf, v)
+	case []any:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		// Properties are sorted by UTF-16 code units
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value %T", v)
+	}
+	return nil
+}
+
+// Escapes string the way ECMAScript JSON.stringify does
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Bitcoin base58 encoding used by base58btc multibase
+func base58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix, mod := big.NewInt(58), new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	// Leading zero bytes are kept as leading ones
+	for i := 0; i < len(b) && b[i] == 0; i++ {
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Certificates are issued as Open Badges 3.0 credentials signed by signer, on behalf of issuer
+func (s *certsServer) IssueCredentials(signer *CredentialSigner, issuer Issuer) {
+	s.credentials = signer
+	s.issuer = issuer
+}
+
+func (s *certsServer) issuerID() string {
+	return s.host + "issuer"
+}
+
+func (s *certsServer) verificationMethodID(pub ed25519.PublicKey) string {
+	return s.issuerID() + "#" + keyID(pub)
+}
+
+// Credential id is link of certificate with .json suffix, see CredentialLinks
+func (s *certsServer) composeCredentialLink(id string) string {
+	return s.composePDFLink(id) + ".json"
+}
+
+// Namespace of name-based UUIDs named by URL, see RFC 9562
+var urlNamespace = [16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// Achievement is template rendered for course. It isn't served, so it is identified by URN:
+// UUID version 5 of host, template name and course, which stays the same for every certificate of them.
+func (s *certsServer) achievementID(template, course string) string {
+	h := sha1.New()
+	h.Write(urlNamespace[:])
+	h.Write([]byte(s.host + "template/" + url.PathEscape(template) + "#" + url.PathEscape(course)))
+	b := h.Sum(nil)[:16]
+	b[6] = b[6]&0x0f | 0x50
+	b[8] = b[8]&0x3f | 0x80
+	u := hex.EncodeToString(b)
+	return "urn:uuid:" + u[:8] + "-" + u[8:12] + "-" + u[12:16] + "-" + u[16:20] + "-" + u[20:]
+}
+
+func (s *certsServer) checkCredentials() error {
+	if s.credentials == nil {
+		return status.Error(codes.Unimplemented, "credentials aren't issued, signing key isn't configured")
+	}
+	return nil
+}
+
+// Issuer profile, keys are listed only in published profile
+func (s *certsServer) issuerProfile() map[string]any {
+	profile := map[string]any{
+		"id":   s.issuerID(),
+		"type": []any{"Profile"},
+		"name": s.issuer.Name,
+	}
+	if s.issuer.URL != "" {
+		profile["url"] = s.issuer.URL
+	}
+	return profile
+}
+
+func (s *certsServer) GetCertificateCredential(ctx context.Context, request *api.GetCertificateCredentialRequest) (*httpbody.HttpBody, error) {
+	if err := s.checkCredentials(); err != nil {
+		return nil, err
+	}
+	cert, err := s.issuedCertificate(ctx, request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.r.GetTemplate(ctx, cert.TemplatePk)
+	if err != nil {
+		return nil, err
+	}
+	validFrom := cert.Timestamp
+	if cert.ValidFrom != nil {
+		validFrom = *cert.ValidFrom
+	}
+	criteria := "Completed " + cert.Course + " course"
+	if cert.Mentors != "" {
+		criteria += " mentored by " + cert.Mentors
+	}
+	credential := map[string]any{
+		"@context":  []any{credentialsContext, openBadgesContext},
+		"id":        s.composeCredentialLink(cert.Id),
+		"type":      []any{"VerifiableCredential", "OpenBadgeCredential"},
+		"name":      cert.Course,
+		"issuer":    s.issuerProfile(),
+		"validFrom": validFrom.UTC().Format(time.RFC3339),
+		"image": map[string]any{
+			"id":   s.composeImageLink(cert.Id, credentialImage),
+			"type": "Image",
+		},
+		"credentialSubject": map[string]any{
+			"type": []any{"AchievementSubject"},
+			// Students are known by name only
+			"identifier": []any{map[string]any{
+				"type":         "IdentityObject",
+				"identityType": "name",
+				"identityHash": cert.Student,
+				"hashed":       false,
+			}},
+			"achievement": map[string]any{
+				"id":              s.achievementID(t.Name, cert.Course),
+				"type":            []any{"Achievement"},
+				"achievementType": "Certificate",
+				"name":            cert.Course,
+				"description":     cert.Course + " course at " + s.issuer.Name,
+				"criteria":        map[string]any{"narrative": criteria},
+				"creator":         s.issuerProfile(),
+			},
+		},
+	}
+	if cert.ValidUntil != nil {
+		credential["validUntil"] = cert.ValidUntil.UTC().Format(time.RFC3339)
+	}
+	if err := s.credentials.sign(credential, s.verificationMethodID(s.credentials.keys[0])); err != nil {
+		return nil, err
+	}
+	return jsonBody("application/ld+json", credential)
+}
+
+func (s *certsServer) GetIssuerProfile(ctx context.Context, request *api.GetIssuerProfileRequest) (*httpbody.HttpBody, error) {
+	if err := s.checkCredentials(); err != nil {
+		return nil, err
+	}
+	profile := s.issuerProfile()
+	profile["@context"] = []any{credentialsContext, openBadgesContext, multikeyContext}
+	var methods, ids []any
+	for _, pub := range s.credentials.keys {
+		methods = append(methods, map[string]any{
+			"id":                 s.verificationMethodID(pub),
+			"type":               "Multikey",
+			"controller":         s.issuerID(),
+			"publicKeyMultibase": multikey(pub),
+		})
+		ids = append(ids, s.verificationMethodID(pub))
+	}
+	profile["verificationMethod"] = methods
+	profile["assertionMethod"] = ids
+	return jsonBody("application/ld+json", profile)
+}
+
+func (s *certsServer) GetIssuerKeys(ctx context.Context, request *api.GetIssuerKeysRequest) (*httpbody.HttpBody, error) {
+	if err := s.checkCredentials(); err != nil {
+		return nil, err
+	}
+	var keys []any
+	for _, pub := range s.credentials.keys {
+		keys = append(keys, map[string]any{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(pub),
+			"kid": keyID(pub),
+			"alg": "EdDSA",
+			"use": "sig",
+		})
+	}
+	return jsonBody("application/jwk-set+json", map[string]any{"keys": keys})
+}
+
+func jsonBody(contentType string, doc map[string]any) (*httpbody.HttpBody, error) {
+	b, err := canonicalJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &httpbody.HttpBody{ContentType: contentType, Data: b}, nil
+}
+
+// Serves .json variants of certificate links, "/certificate/{id}.json" and "/c/{id}.json", as GetCertificateCredential
+func CredentialLinks(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := credentialLinkID(r.URL.Path); ok {
+			r2 := r.Clone(r.Context())
+			r2.URL.Path, r2.URL.RawPath = "/certificate/"+id+"/credential", ""
+			h.ServeHTTP(w, r2)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func credentialLinkID(path string) (string, bool) {
+	if !strings.HasSuffix(path, ".json") {
+		return "", false
+	}
+	for _, prefix := range []string{"/certificate/", "/c/"} {
+		if strings.HasPrefix(path, prefix) {
+			id := strings.TrimSuffix(strings.TrimPrefix(path, prefix), ".json")
+			if id == "" || strings.Contains(id, "/") {
+				return "", false
+			}
+			return id, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,310 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func writeTestPEM(t *testing.T, name string, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		assert.FailNow(t, "failed to write key: %v", err)
+	}
+	return path
+}
+
+func createTestCredentialSigner(t *testing.T, retired ...ed25519.PublicKey) *CredentialSigner {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		assert.FailNow(t, "failed to generate key: %v", err)
+	}
+	c := newCredentialSigner(key, retired...)
+	c.now = func() time.Time { return time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC) }
+	return c
+}
+
+// Verifies eddsa-jcs-2022 proof of document with key
+func verifyTestProof(t *testing.T, doc map[string]any, pub ed25519.PublicKey) bool {
+	proof, ok := doc["proof"].(map[string]any)
+	if !assert.True(t, ok, "document has no proof") {
+		return false
+	}
+	unsecured := map[string]any{}
+	for k, v := range doc {
+		if k != "proof" {
+			unsecured[k] = v
+		}
+	}
+	config := map[string]any{}
+	for k, v := range proof {
+		if k != "proofValue" {
+			config[k] = v
+		}
+	}
+	hash, err := proofHash(unsecured, config)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	value, _ := proof["proofValue"].(string)
+	return assert.True(t, strings.HasPrefix(value, "z")) && ed25519.Verify(pub, hash, decodeTestBase58(t, value[1:]))
+}
+
+func decodeTestBase58(t *testing.T, s string) []byte {
+	n := new(big.Int)
+	for _, c := range s {
+		i := strings.IndexRune(base58Alphabet, c)
+		if i < 0 {
+			assert.FailNow(t, "invalid base58 character %q", c)
+		}
+		n.Mul(n, big.NewInt(58)).Add(n, big.NewInt(int64(i)))
+	}
+	return append(make([]byte, len(s)-len(strings.TrimLeft(s, "1"))), n.Bytes()...)
+}
+
+func Test_canonicalJSON(t *testing.T) {
+	tests := map[string]struct {
+		v      any
+		exp    string
+		expErr string
+	}{
+		"Properties sorted by UTF-16 code units": {
+			map[string]any{"\u20ac": 1, "\r": 2, "\U0001F600": 3, "1": 4, "\u00f6": 5, "\ufb33": 6},
+			"{\"\\r\":2,\"1\":4,\"\u00f6\":5,\"\u20ac\":1,\"\U0001F600\":3,\"\ufb33\":6}", ""},
+		"Strings escaped like JSON.stringify": {
+			[]any{"<a href=\"x\">&</a>", "\u2028\t\u0001", nil, true},
+			`["<a href=\"x\">&</a>","` + "\u2028" + `\t\u0001",null,true]`, ""},
+		"Nested values": {
+			map[string]any{"b": []any{map[string]any{"d": 1, "c": false}}, "a": "x"},
+			`{"a":"x","b":[{"c":false,"d":1}]}`, ""},
+		"Fractional number": {map[string]any{"a": 1.5}, "", "isn't safe integer"},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			got, err := canonicalJSON(tt.v)
+			if tt.expErr != "" {
+				assert.ErrorContains(t, err, tt.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.exp, string(got))
+		})
+	}
+}
+
+func Test_base58(t *testing.T) {
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", base58([]byte("Hello World!")))
+	assert.Equal(t, "112", base58([]byte{0, 0, 1}))
+	assert.Equal(t, "", base58(nil))
+}
+
+func Test_keyID(t *testing.T) {
+	// Example of RFC 8037
+	x, _ := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", keyID(ed25519.PublicKey(x)))
+	assert.True(t, strings.HasPrefix(multikey(ed25519.PublicKey(x)), "z6Mk"))
+}
+
+func Test_NewCredentialSigner(t *testing.T) {
+	pub, key, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	keyFile := writeTestPEM(t, "key.pem", "PRIVATE KEY", der)
+	retiredPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ = x509.MarshalPKIXPublicKey(retiredPub)
+	retiredFile := writeTestPEM(t, "retired.pem", "PUBLIC KEY", der)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ = x509.MarshalPKCS8PrivateKey(ecKey)
+	ecFile := writeTestPEM(t, "ec.pem", "PRIVATE KEY", der)
+
+	c, err := NewCredentialSigner(keyFile, []string{retiredFile})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []ed25519.PublicKey{pub, retiredPub}, c.keys)
+	}
+	_, err = NewCredentialSigner(ecFile, nil)
+	assert.ErrorContains(t, err, "must be Ed25519")
+	_, err = NewCredentialSigner(keyFile, []string{keyFile})
+	assert.ErrorContains(t, err, "failed to parse public key")
+	_, err = NewCredentialSigner(filepath.Join(t.TempDir(), "missing.pem"), nil)
+	assert.ErrorContains(t, err, "failed to read key")
+}
+
+func Test_GetCertificateCredential(t *testing.T) {
+	validUntil := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := Certificate{
+		Id:         "12345678",
+		TemplatePk: 1,
+		Timestamp:  time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+		Student:    "Test Student",
+		IssueDate:  "1 December 2022",
+		Course:     "Test Course",
+		Mentors:    "Test Mentor",
+		ValidUntil: &validUntil,
+	}
+	issuer := Issuer{Name: "Test School", URL: "https://school.example.com/"}
+
+	t.Run("Signed Open Badges credential", func(t *testing.T) {
+		rMock := NewMockRegistry(t)
+		server := NewCertsServer(rMock, NewMockStorage(t), NewMockTemplater(t), host)
+		signer := createTestCredentialSigner(t)
+		server.IssueCredentials(signer, issuer)
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(&cert, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, cert.TemplatePk).Return(&Template{Name: "golang"}, nil)
+
+		got, err := server.GetCertificateCredential(context.Background(), &api.GetCertificateCredentialRequest{Id: cert.Id})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "application/ld+json", got.GetContentType())
+		var doc map[string]any
+		if !assert.NoError(t, json.Unmarshal(got.GetData(), &doc)) {
+			return
+		}
+		assert.Equal(t, host+"certificate/12345678.json", doc["id"])
+		assert.Equal(t, []any{"VerifiableCredential", "OpenBadgeCredential"}, doc["type"])
+		assert.Equal(t, "2022-12-01T10:00:00Z", doc["validFrom"])
+		assert.Equal(t, "2025-06-01T00:00:00Z", doc["validUntil"])
+		assert.Equal(t, map[string]any{"id": host + "issuer", "type": []any{"Profile"}, "name": "Test School", "url": "https://school.example.com/"}, doc["issuer"])
+		subject := doc["credentialSubject"].(map[string]any)
+		assert.Equal(t, "Test Student", subject["identifier"].([]any)[0].(map[string]any)["identityHash"])
+		achievement := subject["achievement"].(map[string]any)
+		// UUID version 5 of http://example.com/template/golang#Test%20Course in URL namespace
+		assert.Equal(t, "urn:uuid:dad036aa-26aa-5486-8cc4-31a8bd288683", achievement["id"])
+		assert.Equal(t, "Test Course", achievement["name"])
+		assert.Equal(t, map[string]any{"narrative": "Completed Test Course course mentored by Test Mentor"}, achievement["criteria"])
+
+		proof := doc["proof"].(map[string]any)
+		assert.Equal(t, "eddsa-jcs-2022", proof["cryptosuite"])
+		assert.Equal(t, "2022-12-16T15:25:14Z", proof["created"])
+		assert.Equal(t, host+"issuer#"+keyID(signer.keys[0]), proof["verificationMethod"])
+		assert.True(t, verifyTestProof(t, doc, signer.keys[0]))
+
+		// Tampered credential isn't verified
+		doc["name"] = "Another Course"
+		assert.False(t, verifyTestProof(t, doc, signer.keys[0]))
+	})
+
+	t.Run("Refuse credential of revoked certificate", func(t *testing.T) {
+		rMock := NewMockRegistry(t)
+		server := NewCertsServer(rMock, NewMockStorage(t), NewMockTemplater(t), host)
+		server.IssueCredentials(createTestCredentialSigner(t), issuer)
+		revokedAt := time.Now()
+		revoked := cert
+		revoked.RevokedAt = &revokedAt
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(&revoked, nil)
+
+		_, err := server.GetCertificateCredential(context.Background(), &api.GetCertificateCredentialRequest{Id: cert.Id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Credentials aren't configured", func(t *testing.T) {
+		server := NewCertsServer(NewMockRegistry(t), NewMockStorage(t), NewMockTemplater(t), host)
+		_, err := server.GetCertificateCredential(context.Background(), &api.GetCertificateCredentialRequest{Id: cert.Id})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = server.GetIssuerProfile(context.Background(), &api.GetIssuerProfileRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = server.GetIssuerKeys(context.Background(), &api.GetIssuerKeysRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("Credential through .json link", func(t *testing.T) {
+		ctx := context.Background()
+		rMock := NewMockRegistry(t)
+		server := NewCertsServer(rMock, NewMockStorage(t), NewMockTemplater(t), host)
+		server.IssueCredentials(createTestCredentialSigner(t), issuer)
+		mux := runtime.NewServeMux()
+		if err := api.RegisterCertsServiceHandlerServer(ctx, mux, server); err != nil {
+			assert.FailNow(t, "unexpected error while registering service handler: %v", err)
+		}
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(&cert, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, cert.TemplatePk).Return(&Template{Name: "golang"}, nil)
+
+		for _, path := range []string{"/certificate/12345678.json", "/c/12345678.json"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			resp := httptest.NewRecorder()
+			CredentialLinks(mux).ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code, path)
+			assert.Equal(t, "application/ld+json", resp.Header().Get("Content-Type"))
+			assert.Contains(t, resp.Body.String(), `"OpenBadgeCredential"`)
+		}
+	})
+}
+
+func Test_GetIssuerProfile(t *testing.T) {
+	retired, _, _ := ed25519.GenerateKey(rand.Reader)
+	signer := createTestCredentialSigner(t, retired)
+	server := NewCertsServer(NewMockRegistry(t), NewMockStorage(t), NewMockTemplater(t), host)
+	server.IssueCredentials(signer, Issuer{Name: "Test School"})
+
+	got, err := server.GetIssuerProfile(context.Background(), &api.GetIssuerProfileRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var profile map[string]any
+	if assert.NoError(t, json.Unmarshal(got.GetData(), &profile)) {
+		assert.Equal(t, host+"issuer", profile["id"])
+		assert.Equal(t, "Test School", profile["name"])
+		assert.NotContains(t, profile, "url")
+		methods := profile["verificationMethod"].([]any)
+		if assert.Len(t, methods, 2) {
+			assert.Equal(t, map[string]any{
+				"id":                 host + "issuer#" + keyID(retired),
+				"type":               "Multikey",
+				"controller":         host + "issuer",
+				"publicKeyMultibase": multikey(retired),
+			}, methods[1])
+		}
+		assert.Equal(t, []any{host + "issuer#" + keyID(signer.keys[0]), host + "issuer#" + keyID(retired)}, profile["assertionMethod"])
+	}
+
+	got, err = server.GetIssuerKeys(context.Background(), &api.GetIssuerKeysRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/jwk-set+json", got.GetContentType())
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if assert.NoError(t, json.Unmarshal(got.GetData(), &jwks)) && assert.Len(t, jwks.Keys, 2) {
+		assert.Equal(t, keyID(signer.keys[0]), jwks.Keys[0]["kid"])
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(signer.keys[0]), jwks.Keys[0]["x"])
+		assert.Equal(t, "OKP", jwks.Keys[0]["kty"])
+	}
+}
+
+func Test_credentialLinkID(t *testing.T) {
+	for path, exp := range map[string]string{
+		"/certificate/12345678.json":            "12345678",
+		"/c/12345678.json":                      "12345678",
+		"/certificate/12345678":                 "",
+		"/certificate/12345678/credential.json": "",
+		"/template/golang.json":                 "",
+		"/c/.json":                              "",
+	} {
+		id, ok := credentialLinkID(path)
+		assert.Equal(t, exp, id, path)
+		assert.Equal(t, exp != "", ok, path)
+	}
+}
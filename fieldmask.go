@@ -0,0 +1,76 @@
+package golangunitedschoolcerts
+
+import (
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Copies field of resource message to update, by field mask path
+type maskSetter[M proto.Message, U any] func(M, *U)
+
+var templateMaskSetters = map[string]maskSetter[*api.Template, TemplateUpdate]{
+	"name":    func(t *api.Template, u *TemplateUpdate) { u.Name = Set(t.GetName()) },
+	"content": func(t *api.Template, u *TemplateUpdate) { u.Content = Set(t.GetContent()) },
+}
+
+var certificateMaskSetters = map[string]maskSetter[*api.Certificate, CertificateUpdate]{
+	"template":  func(c *api.Certificate, u *CertificateUpdate) { u.Template = Set(c.GetTemplate()) },
+	"student":   func(c *api.Certificate, u *CertificateUpdate) { u.Student = Set(c.GetStudent()) },
+	"issueDate": func(c *api.Certificate, u *CertificateUpdate) { u.IssueDate = Set(c.GetIssueDate()) },
+	"course":    func(c *api.Certificate, u *CertificateUpdate) { u.Course = Set(c.GetCourse()) },
+	"mentors":   func(c *api.Certificate, u *CertificateUpdate) { u.Mentors = Set(c.GetMentors()) },
+	"validFrom": func(c *api.Certificate, u *CertificateUpdate) {
+		u.ValidFrom = Set(timeFromProto(c.GetValidFrom()))
+	},
+	"validUntil": func(c *api.Certificate, u *CertificateUpdate) {
+		u.ValidUntil = Set(timeFromProto(c.GetValidUntil()))
+	},
+}
+
+// Output only fields of template, they are ignored in updates
+var templateMaskIgnored = []string{"createdAt", "updatedAt", "certificateCount", "contentHash"}
+
+// Identifier and output only fields of certificate, they are ignored in updates
+var certificateMaskIgnored = []string{"id", "link", "timestamp", "revokedAt", "revocationReason"}
+
+// Converts resource message and field mask to update following https://google.aip.dev/134:
+// empty mask updates populated fields of msg, "*" updates all of them, including cleared ones.
+func updateFromMask[M proto.Message, U any](msg M, mask *fieldmaskpb.FieldMask,
+	setters map[string]maskSetter[M, U], ignored ...string) (u U, err error) {
+	var paths []string
+	switch {
+	case len(mask.GetPaths()) == 0:
+		msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			paths = append(paths, string(fd.Name()))
+			return true
+		})
+	case len(mask.GetPaths()) == 1 && mask.GetPaths()[0] == "*":
+		for p := range setters {
+			paths = append(paths, p)
+		}
+	default:
+		paths = mask.GetPaths()
+	}
+
+	updated := 0
+	for _, p := range paths {
+		if slices.Contains(ignored, p) {
+			continue
+		}
+		set, ok := setters[p]
+		if !ok {
+			return u, status.Errorf(codes.InvalidArgument, "field %q can not be updated", p)
+		}
+		set(msg, &u)
+		updated++
+	}
+	if updated == 0 {
+		return u, status.Error(codes.InvalidArgument, "no fields to update was provided")
+	}
+	return u, nil
+}
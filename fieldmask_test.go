@@ -0,0 +1,45 @@
+package golangunitedschoolcerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func Test_updateFromMask(t *testing.T) {
+	tmpl := &api.Template{Name: "new name"}
+	t.Run("Explicit mask updates listed fields, even empty ones", func(t *testing.T) {
+		u, err := updateFromMask(tmpl, &fieldmaskpb.FieldMask{Paths: []string{"content"}}, templateMaskSetters)
+		assert.NoError(t, err)
+		assert.Equal(t, TemplateUpdate{Content: Set("")}, u)
+	})
+	t.Run("Empty mask updates populated fields", func(t *testing.T) {
+		u, err := updateFromMask(tmpl, nil, templateMaskSetters)
+		assert.NoError(t, err)
+		assert.Equal(t, TemplateUpdate{Name: Set("new name")}, u)
+	})
+	t.Run("Wildcard mask updates all fields", func(t *testing.T) {
+		u, err := updateFromMask(tmpl, &fieldmaskpb.FieldMask{Paths: []string{"*"}}, templateMaskSetters)
+		assert.NoError(t, err)
+		assert.Equal(t, TemplateUpdate{Name: Set("new name"), Content: Set("")}, u)
+	})
+	t.Run("Ignored fields are skipped", func(t *testing.T) {
+		_, err := updateFromMask(&api.Certificate{Id: "1d28bdcd", Link: "link"}, nil,
+			certificateMaskSetters, certificateMaskIgnored...)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "no fields to update was provided")
+	})
+	t.Run("Missing message", func(t *testing.T) {
+		_, err := updateFromMask((*api.Template)(nil), nil, templateMaskSetters)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := updateFromMask(tmpl, &fieldmaskpb.FieldMask{Paths: []string{"name", "owner"}}, templateMaskSetters)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, `field "owner" can not be updated`)
+	})
+}
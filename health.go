@@ -0,0 +1,132 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Dependency which can tell whether it is able to serve requests
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// Time given to every readiness check
+const healthCheckTimeout = 5 * time.Second
+
+var ErrShuttingDown = errors.New("shutting down")
+
+// Liveness and readiness of service, served as /healthz, /readyz and grpc.health.v1 service.
+// Check of grpc.health.v1 runs readiness checks, while Watch only reflects shutdown.
+type Health struct {
+	*health.Server
+	names    []string
+	checks   []HealthChecker
+	shutdown atomic.Bool
+}
+
+func NewHealth() *Health {
+	h := &Health{Server: health.NewServer()}
+	h.SetServingStatus(api.CertsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return h
+}
+
+// Adds dependency checked by readiness, name identifies it in /readyz response
+func (h *Health) AddCheck(name string, c HealthChecker) {
+	h.names = append(h.names, name)
+	h.checks = append(h.checks, c)
+}
+
+// Runs readiness checks concurrently and returns their errors in order checks were added, nil for passed ones
+func (h *Health) Ready(ctx context.Context) []error {
+	errs := make([]error, len(h.checks))
+	if h.shutdown.Load() {
+		for i := range errs {
+			errs[i] = ErrShuttingDown
+		}
+		return errs
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	wg := new(sync.WaitGroup)
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c HealthChecker) {
+			defer wg.Done()
+			errs[i] = c.CheckHealth(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+	return errs
+}
+
+func (h *Health) ready(ctx context.Context) bool {
+	if h.shutdown.Load() {
+		return false
+	}
+	for _, err := range h.Ready(ctx) {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Makes service report that it is not ready, so no new requests are sent to it while in-flight ones are drained
+func (h *Health) Shutdown() {
+	h.shutdown.Store(true)
+	h.Server.Shutdown()
+}
+
+// Runs readiness checks for whole server or CertsService
+func (h *Health) Check(ctx context.Context, request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	switch request.GetService() {
+	case "", api.CertsService_ServiceDesc.ServiceName:
+	default:
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	if !h.ready(ctx) {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// Reports that process is alive
+func (h *Health) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+// Reports result of every readiness check, responding with 503 if any of them failed
+func (h *Health) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	if h.shutdown.Load() {
+		http.Error(w, ErrShuttingDown.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	errs := h.Ready(r.Context())
+	code := http.StatusOK
+	for _, err := range errs {
+		if err != nil {
+			code = http.StatusServiceUnavailable
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(w, "%s: %v\n", h.names[i], err)
+		} else {
+			fmt.Fprintf(w, "%s: ok\n", h.names[i])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type testHealthChecker struct {
+	err error
+}
+
+func (c testHealthChecker) CheckHealth(ctx context.Context) error {
+	return c.err
+}
+
+func newTestHealth(dbErr error) *Health {
+	h := NewHealth()
+	h.AddCheck("postgres", testHealthChecker{dbErr})
+	h.AddCheck("gotenberg", testHealthChecker{})
+	return h
+}
+
+func Test_Health_Ready(t *testing.T) {
+	ctx := context.Background()
+	t.Run("All checks pass", func(t *testing.T) {
+		assert.Equal(t, []error{nil, nil}, newTestHealth(nil).Ready(ctx))
+	})
+	t.Run("Errors are returned in order of checks", func(t *testing.T) {
+		errs := newTestHealth(fmt.Errorf("connection refused")).Ready(ctx)
+		assert.EqualError(t, errs[0], "connection refused")
+		assert.NoError(t, errs[1])
+	})
+	t.Run("Not ready after shutdown", func(t *testing.T) {
+		h := newTestHealth(nil)
+		h.Shutdown()
+		assert.Equal(t, []error{ErrShuttingDown, ErrShuttingDown}, h.Ready(ctx))
+	})
+}
+
+func Test_Health_Check(t *testing.T) {
+	ctx := context.Background()
+	tData := map[string]struct {
+		h       *Health
+		service string
+		exp     healthpb.HealthCheckResponse_ServingStatus
+	}{
+		"Server is ready":      {newTestHealth(nil), "", healthpb.HealthCheckResponse_SERVING},
+		"Service is ready":     {newTestHealth(nil), api.CertsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING},
+		"Dependency is failed": {newTestHealth(fmt.Errorf("connection refused")), "", healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for name, d := range tData {
+		d := d
+		t.Run(name, func(t *testing.T) {
+			resp, err := d.h.Check(ctx, &healthpb.HealthCheckRequest{Service: d.service})
+			assert.NoError(t, err)
+			assert.Equal(t, d.exp, resp.GetStatus())
+		})
+	}
+	t.Run("Unknown service", func(t *testing.T) {
+		_, err := newTestHealth(nil).Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func Test_Health_Handlers(t *testing.T) {
+	serve := func(h http.HandlerFunc) (int, string) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		body, _ := io.ReadAll(w.Result().Body)
+		return w.Code, string(body)
+	}
+	t.Run("Liveness", func(t *testing.T) {
+		code, body := serve(newTestHealth(fmt.Errorf("connection refused")).LivenessHandler)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok\n", body)
+	})
+	t.Run("Ready", func(t *testing.T) {
+		code, body := serve(newTestHealth(nil).ReadinessHandler)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "postgres: ok\ngotenberg: ok\n", body)
+	})
+	t.Run("Not ready", func(t *testing.T) {
+		code, body := serve(newTestHealth(fmt.Errorf("connection refused")).ReadinessHandler)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "postgres: connection refused\ngotenberg: ok\n", body)
+	})
+	t.Run("Shutting down", func(t *testing.T) {
+		h := newTestHealth(nil)
+		h.Shutdown()
+		code, _ := serve(h.ReadinessHandler)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+}
@@ -0,0 +1,307 @@
+package golangunitedschoolcerts
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Generates certificate ids and recognises ids of its own format,
+// so malformed ids can be rejected without querying database
+type IdGenerator interface {
+	NewId() (string, error)
+	Valid(id string) bool
+}
+
+var ErrInvalidId = errors.New("invalid id")
+
+// Implemented by IdGenerator whose ids may be typed in other forms than they are issued in, e.g. in lower case
+type idNormalizer interface {
+	// Returns id in form it was issued in, or id itself when it is not of generator's format
+	Normalize(id string) string
+}
+
+// Format of ids generated by database, when no IdGenerator is configured.
+// Ids of this format are always accepted.
+var LegacyIdFormat IdGenerator = &HexIdGenerator{8}
+
+// Crockford's base32 alphabet, without easily confused I, L, O and U
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Random lowercase hex string of given length
+type HexIdGenerator struct {
+	length int
+}
+
+func NewHexIdGenerator(length int) (*HexIdGenerator, error) {
+	if length < 8 {
+		return nil, fmt.Errorf("hex id length must be at least 8, got %d", length)
+	}
+	return &HexIdGenerator{length}, nil
+}
+
+func (g *HexIdGenerator) NewId() (string, error) {
+	b := make([]byte, (g.length+1)/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return hex.EncodeToString(b)[:g.length], nil
+}
+
+func (g *HexIdGenerator) Valid(id string) bool {
+	if len(id) != g.length {
+		return false
+	}
+	for _, c := range id {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Lexicographically sortable 26 characters id: 48 bits of milliseconds since epoch and 80 random bits,
+// see https://github.com/ulid/spec
+type ULIDGenerator struct {
+	now func() time.Time
+}
+
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{time.Now}
+}
+
+func (g *ULIDGenerator) NewId() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	putMillis(b[:6], g.now())
+
+	// 128 bits are encoded by 5 bits per character, first character holds only 3 bits
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	id := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		id[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(id), nil
+}
+
+func (g *ULIDGenerator) Valid(id string) bool {
+	// Greater first character would overflow 128 bits
+	if len(id) != 26 || id[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if strings.IndexByte(crockford, id[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Time-ordered UUID version 7 in canonical lowercase form, see RFC 9562
+type UUIDv7Generator struct {
+	now func() time.Time
+}
+
+func NewUUIDv7Generator() *UUIDv7Generator {
+	return &UUIDv7Generator{time.Now}
+}
+
+func (g *UUIDv7Generator) NewId() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	putMillis(b[:6], g.now())
+	b[6] = b[6]&0x0f | 0x70
+	b[8] = b[8]&0x3f | 0x80
+
+	h := hex.EncodeToString(b[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
+
+func (g *UUIDv7Generator) Valid(id string) bool {
+	if len(id) != 36 || id[8] != '-' || id[13] != '-' || id[18] != '-' || id[23] != '-' {
+		return false
+	}
+	h := id[:8] + id[9:13] + id[14:18] + id[19:23] + id[24:]
+	if !(&HexIdGenerator{32}).Valid(h) {
+		return false
+	}
+	return h[12] == '7' && strings.IndexByte("89ab", h[16]) >= 0
+}
+
+// Human readable id, e.g. GUS-2022-7K3F-9: prefix, year of issue,
+// groups of 4 random Crockford's base32 characters and check character.
+// Each group adds 20 random bits.
+type HumanIdGenerator struct {
+	prefix string
+	groups int
+	now    func() time.Time
+}
+
+func NewHumanIdGenerator(prefix string, groups int) (*HumanIdGenerator, error) {
+	if prefix == "" || strings.Contains(prefix, "-") {
+		return nil, fmt.Errorf("human id prefix must be non-empty and without '-', got %q", prefix)
+	}
+	if groups < 1 {
+		return nil, fmt.Errorf("human id must have at least one random group, got %d", groups)
+	}
+	return &HumanIdGenerator{prefix, groups, time.Now}, nil
+}
+
+func (g *HumanIdGenerator) NewId() (string, error) {
+	b := make([]byte, 4*g.groups)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	parts := []string{strconv.Itoa(g.now().UTC().Year())}
+	for i := 0; i < g.groups; i++ {
+		group := make([]byte, 4)
+		for j := range group {
+			group[j] = crockford[b[4*i+j]&31]
+		}
+		parts = append(parts, string(group))
+	}
+	payload := strings.Join(parts, "-")
+	return g.prefix + "-" + payload + "-" + string(checkChar(payload)), nil
+}
+
+// Crockford's base32 is case-insensitive and reads I and L as 1, O as 0, so typed ids are accepted in any of these forms
+func (g *HumanIdGenerator) Normalize(id string) string {
+	prefix, payload, ok := strings.Cut(id, "-")
+	if !ok || !strings.EqualFold(prefix, g.prefix) {
+		return id
+	}
+	return g.prefix + "-" + strings.Map(func(r rune) rune {
+		switch r = unicode.ToUpper(r); r {
+		case 'I', 'L':
+			return '1'
+		case 'O':
+			return '0'
+		}
+		return r
+	}, payload)
+}
+
+func (g *HumanIdGenerator) Valid(id string) bool {
+	parts := strings.Split(g.Normalize(id), "-")
+	// prefix, year, groups, check character
+	if len(parts) != g.groups+3 || parts[0] != g.prefix {
+		return false
+	}
+	if year := parts[1]; len(year) != 4 || strings.Trim(year, "0123456789") != "" {
+		return false
+	}
+	for _, group := range parts[2 : len(parts)-1] {
+		if len(group) != 4 || strings.Trim(group, crockford) != "" {
+			return false
+		}
+	}
+	check := parts[len(parts)-1]
+	payload := strings.Join(parts[1:len(parts)-1], "-")
+	return len(check) == 1 && check[0] == checkChar(payload)
+}
+
+// Luhn mod 32 check character over alphabet characters of s, other characters are skipped.
+// Detects any single mistyped character and most transpositions of adjacent ones.
+func checkChar(s string) byte {
+	const n = len(crockford)
+	sum, factor := 0, 2
+	for i := len(s) - 1; i >= 0; i-- {
+		c := strings.IndexByte(crockford, s[i])
+		if c < 0 {
+			continue
+		}
+		addend := factor * c
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return crockford[(n-sum%n)%n]
+}
+
+func putMillis(b []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// Generates ids with the first generator, and accepts ids of any, so ids issued before format change keep resolving
+type idFormats []IdGenerator
+
+func (f idFormats) NewId() (string, error) {
+	return f[0].NewId()
+}
+
+func (f idFormats) Normalize(id string) string {
+	for _, g := range f {
+		if n, ok := g.(idNormalizer); ok && g.Valid(id) {
+			return n.Normalize(id)
+		}
+	}
+	return id
+}
+
+func (f idFormats) Valid(id string) bool {
+	for _, g := range f {
+		if g.Valid(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parses comma separated list of id formats, first of them is used for new ids:
+//   - hex or hex:<length> - random hex string, 16 characters by default
+//   - ulid - ULID
+//   - uuidv7 - UUID version 7
+//   - human or human:<prefix>:<groups> - human readable id, GUS prefix and 3 groups by default
+func ParseIdFormat(s string) (IdGenerator, error) {
+	var f idFormats
+	for _, spec := range strings.Split(s, ",") {
+		args := strings.Split(strings.TrimSpace(spec), ":")
+		var g IdGenerator
+		var err error
+		switch {
+		case args[0] == "hex" && len(args) == 1:
+			g, err = NewHexIdGenerator(16)
+		case args[0] == "hex" && len(args) == 2:
+			var length int
+			if length, err = strconv.Atoi(args[1]); err == nil {
+				g, err = NewHexIdGenerator(length)
+			}
+		case args[0] == "ulid" && len(args) == 1:
+			g = NewULIDGenerator()
+		case args[0] == "uuidv7" && len(args) == 1:
+			g = NewUUIDv7Generator()
+		case args[0] == "human" && len(args) == 1:
+			g, err = NewHumanIdGenerator("GUS", 3)
+		case args[0] == "human" && len(args) == 3:
+			var groups int
+			if groups, err = strconv.Atoi(args[2]); err == nil {
+				g, err = NewHumanIdGenerator(args[1], groups)
+			}
+		default:
+			err = errors.New("unknown format")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid id format %q: %w", spec, err)
+		}
+		f = append(f, g)
+	}
+	if len(f) == 1 {
+		return f[0], nil
+	}
+	return f, nil
+}
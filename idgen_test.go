@@ -0,0 +1,192 @@
+package golangunitedschoolcerts
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HexIdGenerator(t *testing.T) {
+	t.Run("Generates valid ids of given length", func(t *testing.T) {
+		for _, length := range []int{8, 9, 16, 32} {
+			g, err := NewHexIdGenerator(length)
+			assert.NoError(t, err)
+			id, err := g.NewId()
+			assert.NoError(t, err)
+			assert.Len(t, id, length)
+			assert.True(t, g.Valid(id), id)
+		}
+	})
+	t.Run("Too short length", func(t *testing.T) {
+		_, err := NewHexIdGenerator(4)
+		assert.Error(t, err)
+	})
+	t.Run("Rejects malformed ids", func(t *testing.T) {
+		g, _ := NewHexIdGenerator(8)
+		for _, id := range []string{"", "1d28bdc", "1d28bdcd0", "1D28BDCD", "1d28bdcg"} {
+			assert.False(t, g.Valid(id), id)
+		}
+		assert.True(t, g.Valid("1d28bdcd"))
+	})
+}
+
+func Test_ULIDGenerator(t *testing.T) {
+	g := NewULIDGenerator()
+	t.Run("Generates valid ids", func(t *testing.T) {
+		id, err := g.NewId()
+		assert.NoError(t, err)
+		assert.Len(t, id, 26)
+		assert.True(t, g.Valid(id), id)
+	})
+	t.Run("Encodes time in leading characters", func(t *testing.T) {
+		g.now = func() time.Time { return time.UnixMilli(1469918176385) }
+		id, err := g.NewId()
+		assert.NoError(t, err)
+		// Example from ULID specification
+		assert.Equal(t, "01ARYZ6S41", id[:10])
+	})
+	t.Run("Ids are sorted by time", func(t *testing.T) {
+		var ids []string
+		for i := 0; i < 10; i++ {
+			g.now = func() time.Time { return time.UnixMilli(int64(1000 * i)) }
+			id, _ := g.NewId()
+			ids = append(ids, id)
+		}
+		assert.True(t, sort.StringsAreSorted(ids))
+	})
+	t.Run("Rejects malformed ids", func(t *testing.T) {
+		for _, id := range []string{"", "01ARYZ6S41", "81ARYZ6S41TSV4RRFFQ69G5FAV", "01ARYZ6S41TSV4RRFFQ69G5FAU", "01aryz6s41tsv4rrffq69g5fav"} {
+			assert.False(t, g.Valid(id), id)
+		}
+		assert.True(t, g.Valid("01ARYZ6S41TSV4RRFFQ69G5FAV"))
+	})
+}
+
+func Test_UUIDv7Generator(t *testing.T) {
+	g := NewUUIDv7Generator()
+	t.Run("Generates valid ids", func(t *testing.T) {
+		id, err := g.NewId()
+		assert.NoError(t, err)
+		assert.Len(t, id, 36)
+		assert.True(t, g.Valid(id), id)
+	})
+	t.Run("Encodes time in leading characters", func(t *testing.T) {
+		g.now = func() time.Time { return time.UnixMilli(0x017F22E279B0) }
+		id, err := g.NewId()
+		assert.NoError(t, err)
+		// Example from RFC 9562
+		assert.Equal(t, "017f22e2-79b0-7", id[:15])
+	})
+	t.Run("Rejects malformed ids", func(t *testing.T) {
+		for _, id := range []string{"", "017f22e2-79b0-4cc3-98c4-dc0c0c07398f", "017f22e2-79b0-7cc3-18c4-dc0c0c07398f",
+			"017f22e279b07cc398c4dc0c0c07398f", "017F22E2-79B0-7CC3-98C4-DC0C0C07398F"} {
+			assert.False(t, g.Valid(id), id)
+		}
+		assert.True(t, g.Valid("017f22e2-79b0-7cc3-98c4-dc0c0c07398f"))
+	})
+}
+
+func Test_HumanIdGenerator(t *testing.T) {
+	t.Run("Generates valid ids", func(t *testing.T) {
+		g, err := NewHumanIdGenerator("GUS", 2)
+		assert.NoError(t, err)
+		g.now = func() time.Time { return time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC) }
+		id, err := g.NewId()
+		assert.NoError(t, err)
+		assert.Regexp(t, `^GUS-2022-[0-9A-Z]{4}-[0-9A-Z]{4}-[0-9A-Z]$`, id)
+		assert.True(t, g.Valid(id), id)
+	})
+	t.Run("Invalid parameters", func(t *testing.T) {
+		_, err := NewHumanIdGenerator("", 1)
+		assert.Error(t, err)
+		_, err = NewHumanIdGenerator("G-US", 1)
+		assert.Error(t, err)
+		_, err = NewHumanIdGenerator("GUS", 0)
+		assert.Error(t, err)
+	})
+	t.Run("Detects mistyped characters", func(t *testing.T) {
+		g, _ := NewHumanIdGenerator("GUS", 1)
+		id := "GUS-2022-7K3F-" + string(checkChar("2022-7K3F"))
+		assert.True(t, g.Valid(id))
+		// Every single character substitution in payload or check character is detected
+		for i := len("GUS-"); i < len(id); i++ {
+			if id[i] == '-' {
+				continue
+			}
+			for _, c := range crockford {
+				if byte(c) == id[i] {
+					continue
+				}
+				typo := id[:i] + string(c) + id[i+1:]
+				assert.False(t, g.Valid(typo), typo)
+			}
+		}
+		// Swapped adjacent characters
+		assert.False(t, g.Valid("GUS-2022-K73F-"+string(checkChar("2022-7K3F"))))
+	})
+	t.Run("Accepts ids typed in lower case or with I, L and O", func(t *testing.T) {
+		g, _ := NewHumanIdGenerator("GUS", 1)
+		id := "GUS-2022-7K10-" + string(checkChar("2022-7K10"))
+		for _, typed := range []string{id, strings.ToLower(id), "gus-2022-7kio-" + id[len(id)-1:], "Gus-2022-7KL0-" + id[len(id)-1:]} {
+			assert.True(t, g.Valid(typed), typed)
+			assert.Equal(t, id, g.Normalize(typed))
+		}
+		// Ids of other formats are left as they are
+		assert.Equal(t, "1d28bdcd", g.Normalize("1d28bdcd"))
+		assert.Equal(t, "ABC-2022-7kio-1", g.Normalize("ABC-2022-7kio-1"))
+	})
+	t.Run("Rejects malformed ids", func(t *testing.T) {
+		g, _ := NewHumanIdGenerator("GUS", 1)
+		check := string(checkChar("2022-7K3F"))
+		for _, id := range []string{"", "1d28bdcd", "ABC-2022-7K3F-" + check, "GUS-22-7K3F-" + check,
+			"GUS-2022-7K3F", "GUS-2022-7K3F-0000-" + check, "GUS-2022-7K3U-" + check} {
+			assert.False(t, g.Valid(id), id)
+		}
+	})
+}
+
+func Test_ParseIdFormat(t *testing.T) {
+	t.Run("Single formats", func(t *testing.T) {
+		tests := map[string]IdGenerator{
+			"hex":       &HexIdGenerator{16},
+			"hex:24":    &HexIdGenerator{24},
+			"ulid":      &ULIDGenerator{},
+			"uuidv7":    &UUIDv7Generator{},
+			"human":     &HumanIdGenerator{prefix: "GUS", groups: 3},
+			"human:C:2": &HumanIdGenerator{prefix: "C", groups: 2},
+		}
+		for spec, exp := range tests {
+			g, err := ParseIdFormat(spec)
+			assert.NoError(t, err, spec)
+			assert.IsType(t, exp, g, spec)
+			id, err := g.NewId()
+			assert.NoError(t, err)
+			assert.True(t, g.Valid(id), id)
+			if h, ok := exp.(*HexIdGenerator); ok {
+				assert.Len(t, id, h.length)
+			}
+			if h, ok := exp.(*HumanIdGenerator); ok {
+				assert.True(t, strings.HasPrefix(id, h.prefix+"-"), id)
+			}
+		}
+	})
+	t.Run("Several formats generate with the first and accept all", func(t *testing.T) {
+		g, err := ParseIdFormat("human, ulid")
+		assert.NoError(t, err)
+		id, err := g.NewId()
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, "GUS-"), id)
+		assert.True(t, g.Valid(id))
+		assert.True(t, g.Valid("01ARYZ6S41TSV4RRFFQ69G5FAV"))
+		assert.False(t, g.Valid("1d28bdcd"))
+	})
+	t.Run("Invalid formats", func(t *testing.T) {
+		for _, spec := range []string{"", "uuid", "hex:x", "hex:4", "ulid:1", "human:GUS", "human:GUS:x", "ulid,"} {
+			_, err := ParseIdFormat(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package golangunitedschoolcerts
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Storage keeping certificates in process memory, fastest tier of TieredStorage
+type MemStorage struct {
+	cache Cache[string, certFile]
+}
+
+type certFile struct {
+	timestamp time.Time
+	file      []byte
+	checksum  string
+}
+
+// Primitive counter for now
+func (c certFile) Size() int {
+	return 1
+}
+
+var _ Storage = &MemStorage{}
+
+// Keeps up to capacity certificates, zero means unlimited
+func NewMemStorage(capacity int) (*MemStorage, error) {
+	c, err := NewLRUCache[string, certFile](capacity, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+	return &MemStorage{NewSafeCache[string, certFile](c)}, nil
+}
+
+func (s *MemStorage) Add(id string, timestamp time.Time, cert *[]byte) error {
+	cf, ok := s.cache.Peek(id)
+	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.After(timestamp)) {
+		return nil
+	}
+	s.cache.Add(id, certFile{timestamp, *cert, checksum(*cert)})
+	return nil
+}
+
+func (s *MemStorage) Get(id string, timestamp time.Time) (*[]byte, error) {
+	cf, ok := s.cache.Peek(id)
+	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.After(timestamp)) {
+		s.cache.Touch(id)
+		return &cf.file, nil
+	}
+	return nil, fmt.Errorf("no certificate found in memory for such id: %v and timestamp: %v", id, timestamp)
+}
+
+// Reader shares certificate with storage, nothing is copied
+func (s *MemStorage) Open(id string, timestamp time.Time) (io.ReadCloser, error) {
+	cert, err := s.Get(id, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return bytesFile{bytes.NewReader(*cert)}, nil
+}
+
+// Seekable io.ReadCloser over certificate in memory
+type bytesFile struct {
+	*bytes.Reader
+}
+
+func (bytesFile) Close() error {
+	return nil
+}
+
+func (s *MemStorage) Contains(id string, timestamp time.Time) bool {
+	cf, ok := s.cache.Peek(id)
+	return ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.After(timestamp))
+}
+
+func (s *MemStorage) Delete(id string, timestamp time.Time) {
+	cf, ok := s.cache.Peek(id)
+	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.Before(timestamp)) {
+		s.cache.Remove(id)
+	}
+}
+
+// Memory is empty on start
+func (s *MemStorage) Load() error {
+	return nil
+}
+
+func (s *MemStorage) Checksum(id string, timestamp time.Time) (string, bool) {
+	cf, ok := s.cache.Peek(id)
+	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.After(timestamp)) {
+		return cf.checksum, true
+	}
+	return "", false
+}
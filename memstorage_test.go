@@ -0,0 +1,155 @@
+package golangunitedschoolcerts
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestMemStorage(t *testing.T, capacity int) *MemStorage {
+	s, err := NewMemStorage(capacity)
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating memory storage: %v", err)
+	}
+	return s
+}
+
+func Test_NewMemStorage(t *testing.T) {
+	_, err := NewMemStorage(-1)
+	assert.Error(t, err)
+}
+
+func Test_MemStorage_Get(t *testing.T) {
+	id := "id"
+
+	t.Run("Get a certificate from empty storage", func(t *testing.T) {
+		s := createTestMemStorage(t, 0)
+		actCert, err := s.Get(id, time.Now())
+		assert.Nil(t, actCert)
+		assert.Error(t, err)
+	})
+
+	t.Run("Get a new certificate from the storage", func(t *testing.T) {
+		s := createTestMemStorage(t, 0)
+		expCert := []byte{1, 1, 1, 1}
+		now := time.Now()
+
+		assert.NoError(t, s.Add(id, now, &expCert))
+		actCert, err := s.Get(id, now)
+		assert.NoError(t, err)
+		assert.Equal(t, expCert, *actCert)
+	})
+
+	t.Run("Replace an old certificate", func(t *testing.T) {
+		s := createTestMemStorage(t, 0)
+		oldCf := certFile{
+			timestamp: time.Now(),
+			file:      []byte{1, 1, 1, 1},
+		}
+		newCf := certFile{
+			timestamp: oldCf.timestamp.Add(1 * time.Hour),
+			file:      []byte{2, 2, 2, 2},
+		}
+		oldCf.checksum = checksum(oldCf.file)
+		newCf.checksum = checksum(newCf.file)
+
+		assert.NoError(t, s.Add(id, oldCf.timestamp, &oldCf.file))
+		cf1, ok := s.cache.Peek(id)
+		assert.True(t, ok)
+		assert.Equal(t, oldCf, *cf1)
+
+		assert.NoError(t, s.Add(id, newCf.timestamp, &newCf.file))
+		actCert, err := s.Get(id, newCf.timestamp)
+		assert.NoError(t, err)
+		assert.Equal(t, newCf.file, *actCert)
+		cf2, ok := s.cache.Peek(id)
+		assert.True(t, ok)
+		assert.Equal(t, newCf, *cf2)
+
+		// Older certificate doesn't replace newer one
+		assert.NoError(t, s.Add(id, oldCf.timestamp, &oldCf.file))
+		cf3, _ := s.cache.Peek(id)
+		assert.Equal(t, newCf, *cf3)
+	})
+
+	t.Run("Least recently read certificate is evicted", func(t *testing.T) {
+		s := createTestMemStorage(t, 2)
+		now := time.Now()
+		for _, id := range []string{"first", "second"} {
+			assert.NoError(t, s.Add(id, now, &[]byte{1}))
+		}
+		_, err := s.Get("first", now)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Add("third", now, &[]byte{1}))
+		assert.True(t, s.Contains("first", now))
+		assert.False(t, s.Contains("second", now))
+	})
+}
+
+func Test_MemStorage_Open(t *testing.T) {
+	s := createTestMemStorage(t, 0)
+	now := time.Now()
+	_, err := s.Open("id", now)
+	assert.Error(t, err)
+
+	cert := []byte{1, 2, 3}
+	assert.NoError(t, s.Add("id", now, &cert))
+	r, err := s.Open("id", now)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = r.(io.Seeker).Seek(1, io.SeekStart)
+	assert.NoError(t, err)
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 3}, got)
+	assert.NoError(t, r.Close())
+}
+
+func Test_MemStorage_Contains(t *testing.T) {
+	s := createTestMemStorage(t, 0)
+	now := time.Now()
+	assert.False(t, s.Contains("id", now))
+
+	assert.NoError(t, s.Add("id", now, &[]byte{}))
+	assert.True(t, s.Contains("id", now))
+	assert.True(t, s.Contains("id", now.Add(-1*time.Hour)))
+	assert.False(t, s.Contains("id", now.Add(1*time.Hour)))
+}
+
+func Test_MemStorage_Delete(t *testing.T) {
+	id := "id"
+
+	t.Run("Delete a certificate from the storage", func(t *testing.T) {
+		s := createTestMemStorage(t, 0)
+		now := time.Now()
+		assert.NoError(t, s.Add(id, now, &[]byte{1, 1, 1, 1}))
+
+		s.Delete(id, now)
+		assert.False(t, s.Contains(id, now))
+	})
+
+	t.Run("Don't delete a new certificate with earlier timestamp", func(t *testing.T) {
+		s := createTestMemStorage(t, 0)
+		now := time.Now()
+		assert.NoError(t, s.Add(id, now, &[]byte{1, 1, 1, 1}))
+
+		s.Delete(id, now.Add(-1*time.Hour))
+		assert.True(t, s.Contains(id, now))
+	})
+}
+
+func Test_MemStorage_Checksum(t *testing.T) {
+	s := createTestMemStorage(t, 0)
+	cert := []byte{1, 1, 1, 1}
+	now := time.Now()
+	_, ok := s.Checksum("id", now)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Add("id", now, &cert))
+	sum, ok := s.Checksum("id", now)
+	assert.True(t, ok)
+	assert.Equal(t, checksum(cert), sum)
+}
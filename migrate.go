@@ -0,0 +1,250 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Schema migrations shipped with the service, named <version>_<name>.up.sql and <version>_<name>.down.sql
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Key of advisory lock, which serialises migrators of all service instances
+const migrationLockKey = 0x63657274
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version     INT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    applied_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// Migration and time it was applied at, nil for pending one
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Applies migrations one per transaction, recording applied ones in schema_migrations table.
+// Service instances starting at once wait for each other on advisory lock.
+type Migrator struct {
+	p          pool
+	migrations []Migration
+}
+
+// Migrator of embedded migrations with its own connection pool, which Close releases
+func NewMigrator(connString string) (*Migrator, error) {
+	p, err := initDB(connString)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newMigrator(p, migrationFiles)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+func newMigrator(p pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{p: p, migrations: migrations}, nil
+}
+
+// Reads migrations from fsys sorted by version, versions must go one by one from 1 and have both directions
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		base := file[len("migrations/"):]
+		match := migrationFileName.FindStringSubmatch(base)
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", base)
+		}
+		version, _ := strconv.Atoi(match[1])
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, m.Name, match[2])
+		}
+		sql, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			m.up = string(sql)
+		} else {
+			m.down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration %d_%s is out of sequence, expected version %d", m.Version, m.Name, i+1)
+		}
+	}
+	return migrations, nil
+}
+
+func (m *Migrator) Close() {
+	m.p.Close()
+}
+
+// Runs fn in transaction holding migration lock, with schema_migrations table in place
+func (m *Migrator) inLockedTx(ctx context.Context, fn func(pgx.Tx) error) (err error) {
+	tx, err := m.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("unable to acquire migration lock: %w", err)
+	}
+	if _, err = tx.Exec(ctx, createSchemaMigrations); err != nil {
+		return fmt.Errorf("unable to CREATE TABLE schema_migrations: %w", err)
+	}
+	return fn(tx)
+}
+
+// Applies pending migrations in order and returns them
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	for _, migration := range m.migrations {
+		done := false
+		err := m.inLockedTx(ctx, func(tx pgx.Tx) error {
+			// Another instance could apply it while we waited for lock
+			var exists bool
+			err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version=$1)",
+				migration.Version).Scan(&exists)
+			if err != nil {
+				return fmt.Errorf("unable to SELECT FROM schema_migrations: %w", err)
+			}
+			if exists {
+				return nil
+			}
+			if _, err = tx.Exec(ctx, migration.up); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err = tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+				migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("unable to INSERT INTO schema_migrations: %w", err)
+			}
+			done = true
+			return nil
+		})
+		if err != nil {
+			return applied, err
+		}
+		if done {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Reverts the latest applied migration and returns it
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+	err := m.inLockedTx(ctx, func(tx pgx.Tx) error {
+		var version int
+		err := tx.QueryRow(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("no applied migrations to revert")
+		}
+		if err != nil {
+			return fmt.Errorf("unable to SELECT FROM schema_migrations: %w", err)
+		}
+		if version < 1 || version > len(m.migrations) {
+			return fmt.Errorf("migration %d is unknown to this build, revert it with the build which applied it", version)
+		}
+		migration := m.migrations[version-1]
+		if _, err = tx.Exec(ctx, migration.down); err != nil {
+			return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err = tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version=$1", version); err != nil {
+			return fmt.Errorf("unable to DELETE FROM schema_migrations: %w", err)
+		}
+		reverted = &migration
+		return nil
+	})
+	return reverted, err
+}
+
+// Lists known migrations and applied ones unknown to this build, ordered by version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var status []MigrationStatus
+	err := m.inLockedTx(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+		if err != nil {
+			return fmt.Errorf("unable to SELECT FROM schema_migrations: %w", err)
+		}
+		applied, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (s MigrationStatus, err error) {
+			err = row.Scan(&s.Version, &s.Name, &s.AppliedAt)
+			return
+		})
+		if err != nil {
+			return fmt.Errorf("unable to SELECT FROM schema_migrations: %w", err)
+		}
+
+		i := 0
+		for _, migration := range m.migrations {
+			for i < len(applied) && applied[i].Version < migration.Version {
+				status = append(status, applied[i])
+				i++
+			}
+			s := MigrationStatus{Migration: migration}
+			if i < len(applied) && applied[i].Version == migration.Version {
+				s.AppliedAt = applied[i].AppliedAt
+				i++
+			}
+			status = append(status, s)
+		}
+		status = append(status, applied[i:]...)
+		return nil
+	})
+	return status, err
+}
@@ -0,0 +1,244 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = fstest.MapFS{
+	"migrations/0001_first.up.sql":    {Data: []byte("CREATE TABLE first ()")},
+	"migrations/0001_first.down.sql":  {Data: []byte("DROP TABLE first")},
+	"migrations/0002_second.up.sql":   {Data: []byte("CREATE TABLE second ()")},
+	"migrations/0002_second.down.sql": {Data: []byte("DROP TABLE second")},
+}
+
+func expectMigrationLock(mock pgxmock.PgxPoolIface) {
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(migrationLockKey).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+}
+
+func expectMigrationApplied(mock pgxmock.PgxPoolIface, version int, applied bool) {
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(version).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(applied))
+}
+
+func Test_loadMigrations(t *testing.T) {
+	t.Run("Embedded migrations are valid", func(t *testing.T) {
+		migrations, err := loadMigrations(migrationFiles)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, migrations)
+		assert.Equal(t, "initial", migrations[0].Name)
+	})
+	t.Run("Migrations are sorted by version", func(t *testing.T) {
+		migrations, err := loadMigrations(testMigrations)
+		assert.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "first", up: "CREATE TABLE first ()", down: "DROP TABLE first"},
+			{Version: 2, Name: "second", up: "CREATE TABLE second ()", down: "DROP TABLE second"},
+		}, migrations)
+	})
+	for name, fsys := range map[string]fstest.MapFS{
+		"Missing down file": {
+			"migrations/0001_first.up.sql": {Data: []byte("SELECT 1")},
+		},
+		"Gap in versions": {
+			"migrations/0001_first.up.sql":   {Data: []byte("SELECT 1")},
+			"migrations/0001_first.down.sql": {Data: []byte("SELECT 1")},
+			"migrations/0003_third.up.sql":   {Data: []byte("SELECT 1")},
+			"migrations/0003_third.down.sql": {Data: []byte("SELECT 1")},
+		},
+		"Names of directions differ": {
+			"migrations/0001_first.up.sql":   {Data: []byte("SELECT 1")},
+			"migrations/0001_other.down.sql": {Data: []byte("SELECT 1")},
+		},
+		"Unexpected file name": {
+			"migrations/first.sql": {Data: []byte("SELECT 1")},
+		},
+	} {
+		fsys := fsys
+		t.Run(name, func(t *testing.T) {
+			_, err := loadMigrations(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_Migrator_Up(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Applies only pending migrations", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		expectMigrationLock(mock)
+		expectMigrationApplied(mock, 1, true)
+		mock.ExpectCommit()
+		expectMigrationLock(mock)
+		expectMigrationApplied(mock, 2, false)
+		mock.ExpectExec("CREATE TABLE second").WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "second").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+
+		applied, err := m.Up(ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, applied, 1) {
+			assert.Equal(t, 2, applied[0].Version)
+		}
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+	t.Run("Stops at failed migration", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		expectMigrationLock(mock)
+		expectMigrationApplied(mock, 1, false)
+		mock.ExpectExec("CREATE TABLE first").WillReturnError(fmt.Errorf("syntax error"))
+		mock.ExpectRollback()
+
+		applied, err := m.Up(ctx)
+		assert.ErrorContains(t, err, "failed to apply migration 1_first: syntax error")
+		assert.Empty(t, applied)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+	t.Run("Lock can not be acquired", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(migrationLockKey).
+			WillReturnError(fmt.Errorf("canceling statement due to lock timeout"))
+		mock.ExpectRollback()
+
+		_, err = m.Up(ctx)
+		assert.ErrorContains(t, err, "unable to acquire migration lock")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
+func Test_Migrator_Down(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Reverts the latest migration", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		expectMigrationLock(mock)
+		mock.ExpectQuery("SELECT version FROM schema_migrations").
+			WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(2))
+		mock.ExpectExec("DROP TABLE second").WillReturnResult(pgxmock.NewResult("DROP TABLE", 0))
+		mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(2).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectCommit()
+
+		reverted, err := m.Down(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "second", reverted.Name)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+	t.Run("Nothing to revert", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		expectMigrationLock(mock)
+		mock.ExpectQuery("SELECT version FROM schema_migrations").WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		reverted, err := m.Down(ctx)
+		assert.ErrorContains(t, err, "no applied migrations")
+		assert.Nil(t, reverted)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+	t.Run("Latest migration is unknown", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		expectMigrationLock(mock)
+		mock.ExpectQuery("SELECT version FROM schema_migrations").
+			WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(3))
+		mock.ExpectRollback()
+
+		_, err = m.Down(ctx)
+		assert.ErrorContains(t, err, "migration 3 is unknown to this build")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
+func Test_Migrator_Status(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Lists applied, pending and unknown migrations", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		appliedAt := time.Now()
+		m, err := newMigrator(mock, testMigrations)
+		assert.NoError(t, err)
+		expectMigrationLock(mock)
+		mock.ExpectQuery("SELECT version, name, applied_at FROM schema_migrations").
+			WillReturnRows(pgxmock.NewRows([]string{"version", "name", "applied_at"}).
+				AddRow(1, "first", &appliedAt).
+				AddRow(3, "third", &appliedAt))
+		mock.ExpectCommit()
+
+		status, err := m.Status(ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, status, 3) {
+			assert.Equal(t, "first", status[0].Name)
+			assert.Equal(t, &appliedAt, status[0].AppliedAt)
+			assert.Equal(t, "second", status[1].Name)
+			assert.Nil(t, status[1].AppliedAt)
+			assert.Equal(t, 3, status[2].Version)
+			assert.Equal(t, "third", status[2].Name)
+			assert.NotNil(t, status[2].AppliedAt)
+		}
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS certificate;
+DROP TABLE IF EXISTS template;
+DROP TABLE IF EXISTS template_content;
+DROP FUNCTION IF EXISTS generate_id();
+DROP FUNCTION IF EXISTS update_timestamp();
+-- pgcrypto is left installed, other schemas may use it
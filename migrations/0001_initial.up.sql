@@ -1,3 +1,4 @@
+-- Schema as it was created by postgres/init/init.sql, existing databases adopt it as is
 CREATE TABLE IF NOT EXISTS template_content (
     id      SERIAL PRIMARY KEY,
     content TEXT NOT NULL
@@ -66,10 +67,6 @@ CREATE OR REPLACE TRIGGER update_timestamp_certificate
     BEFORE INSERT OR UPDATE ON certificate
     FOR EACH ROW EXECUTE PROCEDURE update_timestamp();
 
---CREATE OR REPLACE TRIGGER update_timestamp_template
---    AFTER UPDATE ON template
---    FOR EACH ROW EXECUTE PROCEDURE update_timestamp();
-
 CREATE OR REPLACE TRIGGER update_timestamp_template_content
     AFTER UPDATE ON template_content
     FOR EACH ROW EXECUTE PROCEDURE update_timestamp();
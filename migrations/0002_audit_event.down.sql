@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_event;
+DROP FUNCTION IF EXISTS forbid_audit_change();
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS audit_event (
+    id          BIGSERIAL PRIMARY KEY,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    actor       TEXT NOT NULL,
+    action      TEXT NOT NULL,
+    entity      TEXT NOT NULL,
+    entity_id   TEXT NOT NULL,
+    before      JSONB,
+    after       JSONB
+);
+
+CREATE INDEX IF NOT EXISTS audit_event_entity_id ON audit_event (entity_id, created_at);
+
+CREATE OR REPLACE FUNCTION forbid_audit_change() RETURNS TRIGGER AS $forbid_audit_change$
+    BEGIN
+        RAISE EXCEPTION 'audit_event is append-only';
+    END;
+$forbid_audit_change$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER audit_event_append_only
+    BEFORE UPDATE OR DELETE ON audit_event
+    FOR EACH ROW EXECUTE PROCEDURE forbid_audit_change();
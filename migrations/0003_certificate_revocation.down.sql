@@ -0,0 +1,3 @@
+ALTER TABLE certificate
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS revocation_reason;
@@ -0,0 +1,3 @@
+ALTER TABLE certificate
+    ADD COLUMN IF NOT EXISTS revoked_at         TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS revocation_reason  TEXT;
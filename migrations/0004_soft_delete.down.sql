@@ -0,0 +1,11 @@
+-- Soft-deleted rows are discarded, otherwise they would come back to life
+DELETE FROM certificate WHERE deleted_at IS NOT NULL;
+DELETE FROM template WHERE deleted_at IS NOT NULL;
+DELETE FROM template_content
+    WHERE NOT EXISTS (SELECT 1 FROM template WHERE template.content = template_content.id);
+
+DROP INDEX IF EXISTS template_name;
+ALTER TABLE template ADD CONSTRAINT template_name_key UNIQUE (name);
+
+ALTER TABLE certificate DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE template DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+ALTER TABLE template ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE certificate ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Name must be unique only among live templates, so a deleted one can be recreated
+ALTER TABLE template DROP CONSTRAINT IF EXISTS template_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS template_name ON template (name) WHERE deleted_at IS NULL;
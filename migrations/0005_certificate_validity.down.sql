@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS certificate_valid_until;
+ALTER TABLE certificate
+    DROP COLUMN IF EXISTS valid_from,
+    DROP COLUMN IF EXISTS valid_until;
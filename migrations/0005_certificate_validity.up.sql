@@ -0,0 +1,8 @@
+ALTER TABLE certificate
+    ADD COLUMN IF NOT EXISTS valid_from   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS valid_until  TIMESTAMPTZ;
+
+ALTER TABLE certificate DROP CONSTRAINT IF EXISTS certificate_validity;
+ALTER TABLE certificate ADD CONSTRAINT certificate_validity CHECK (valid_until > valid_from);
+
+CREATE INDEX IF NOT EXISTS certificate_valid_until ON certificate (valid_until) WHERE deleted_at IS NULL;
@@ -0,0 +1,23 @@
+-- Generates legacy 8 hex characters id, unless application provided one
+CREATE OR REPLACE FUNCTION generate_id() RETURNS TRIGGER AS $generate_id$
+    DECLARE
+        new_id  TEXT;
+        counter     INT;
+    BEGIN
+        IF NEW.id IS NOT NULL THEN
+            RETURN NEW;
+        END IF;
+        counter = 0;
+        LOOP
+            new_id = encode(gen_random_bytes(4), 'hex');
+            IF (SELECT id FROM certificate WHERE id=new_id) IS NULL THEN
+                NEW.id = new_id;
+                RETURN NEW;
+            END IF;
+            counter = counter + 1;
+            IF counter = 10 THEN
+                RAISE EXCEPTION 'ID generation failed. Retry limit exceeded';
+            END IF;
+        END LOOP;
+    END
+$generate_id$ LANGUAGE plpgsql;
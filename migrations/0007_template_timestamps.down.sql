@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS touch_template ON template;
+DROP FUNCTION IF EXISTS touch_template();
+
+CREATE OR REPLACE FUNCTION update_timestamp() RETURNS TRIGGER AS $update_timestamp$
+    BEGIN
+	IF TG_TABLE_NAME = 'certificate' THEN
+		NEW.timestamp = now();
+		RETURN NEW;
+	ELSIF TG_TABLE_NAME = 'template_content' THEN
+		UPDATE certificate SET timestamp = now() FROM template
+		WHERE template.content = NEW.id AND certificate.template = template.id ;
+	END IF;
+	RETURN NULL;
+    END;
+$update_timestamp$ LANGUAGE plpgsql;
+
+ALTER TABLE template
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,32 @@
+-- Templates existing before migration get its time as creation time
+ALTER TABLE template
+    ADD COLUMN IF NOT EXISTS created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at  TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE OR REPLACE FUNCTION update_timestamp() RETURNS TRIGGER AS $update_timestamp$
+    BEGIN
+	IF TG_TABLE_NAME = 'certificate' THEN
+		NEW.timestamp = now();
+		RETURN NEW;
+	ELSIF TG_TABLE_NAME = 'template_content' THEN
+		UPDATE certificate SET timestamp = now() FROM template
+		WHERE template.content = NEW.id AND certificate.template = template.id ;
+		UPDATE template SET updated_at = now() WHERE content = NEW.id;
+	END IF;
+	RETURN NULL;
+    END;
+$update_timestamp$ LANGUAGE plpgsql;
+
+-- Renaming changes template, deletion and restoration do not
+CREATE OR REPLACE FUNCTION touch_template() RETURNS TRIGGER AS $touch_template$
+    BEGIN
+        IF NEW.name IS DISTINCT FROM OLD.name THEN
+            NEW.updated_at = now();
+        END IF;
+        RETURN NEW;
+    END;
+$touch_template$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER touch_template
+    BEFORE UPDATE ON template
+    FOR EACH ROW EXECUTE PROCEDURE touch_template();
@@ -0,0 +1 @@
+ALTER TABLE audit_event DROP COLUMN IF EXISTS on_behalf_of;
@@ -0,0 +1,2 @@
+-- Actor is verified client, actor claimed by client is kept apart
+ALTER TABLE audit_event ADD COLUMN IF NOT EXISTS on_behalf_of TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS audit_event_entity;
+CREATE INDEX IF NOT EXISTS audit_event_entity_id ON audit_event (entity_id, created_at);
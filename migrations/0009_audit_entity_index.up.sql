@@ -0,0 +1,3 @@
+-- Events are looked up by entity type along with id, as template primary keys and certificate ids may coincide
+DROP INDEX IF EXISTS audit_event_entity_id;
+CREATE INDEX IF NOT EXISTS audit_event_entity ON audit_event (entity, entity_id, created_at);
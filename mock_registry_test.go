@@ -2,7 +2,12 @@
 
 package golangunitedschoolcerts
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockRegistry is an autogenerated mock type for the Registry type
 type MockRegistry struct {
@@ -17,13 +22,13 @@ func (_m *MockRegistry) EXPECT() *MockRegistry_Expecter {
 	return &MockRegistry_Expecter{mock: &_m.Mock}
 }
 
-// AddCertificate provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
-func (_m *MockRegistry) AddCertificate(_a0 string, _a1 string, _a2 string, _a3 string, _a4 string) (*Certificate, error) {
-	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+// AddCertificate provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5, _a6, _a7
+func (_m *MockRegistry) AddCertificate(_a0 context.Context, _a1 string, _a2 string, _a3 string, _a4 string, _a5 string, _a6 *time.Time, _a7 *time.Time) (*Certificate, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5, _a6, _a7)
 
 	var r0 *Certificate
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string) *Certificate); ok {
-		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, *time.Time, *time.Time) *Certificate); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4, _a5, _a6, _a7)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*Certificate)
@@ -31,8 +36,8 @@ func (_m *MockRegistry) AddCertificate(_a0 string, _a1 string, _a2 string, _a3 s
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, string, string) error); ok {
-		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, *time.Time, *time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4, _a5, _a6, _a7)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,18 +51,21 @@ type MockRegistry_AddCertificate_Call struct {
 }
 
 // AddCertificate is a helper method to define mock.On call
-//   - _a0 string
+//   - _a0 context.Context
 //   - _a1 string
 //   - _a2 string
 //   - _a3 string
 //   - _a4 string
-func (_e *MockRegistry_Expecter) AddCertificate(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}, _a4 interface{}) *MockRegistry_AddCertificate_Call {
-	return &MockRegistry_AddCertificate_Call{Call: _e.mock.On("AddCertificate", _a0, _a1, _a2, _a3, _a4)}
+//   - _a5 string
+//   - _a6 *time.Time
+//   - _a7 *time.Time
+func (_e *MockRegistry_Expecter) AddCertificate(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}, _a4 interface{}, _a5 interface{}, _a6 interface{}, _a7 interface{}) *MockRegistry_AddCertificate_Call {
+	return &MockRegistry_AddCertificate_Call{Call: _e.mock.On("AddCertificate", _a0, _a1, _a2, _a3, _a4, _a5, _a6, _a7)}
 }
 
-func (_c *MockRegistry_AddCertificate_Call) Run(run func(_a0 string, _a1 string, _a2 string, _a3 string, _a4 string)) *MockRegistry_AddCertificate_Call {
+func (_c *MockRegistry_AddCertificate_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 string, _a4 string, _a5 string, _a6 *time.Time, _a7 *time.Time)) *MockRegistry_AddCertificate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(string), args[6].(*time.Time), args[7].(*time.Time))
 	})
 	return _c
 }
@@ -67,13 +75,13 @@ func (_c *MockRegistry_AddCertificate_Call) Return(_a0 *Certificate, _a1 error)
 	return _c
 }
 
-// AddTemplate provides a mock function with given fields: _a0, _a1
-func (_m *MockRegistry) AddTemplate(_a0 string, _a1 string) error {
-	ret := _m.Called(_a0, _a1)
+// AddTemplate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRegistry) AddTemplate(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,15 +95,16 @@ type MockRegistry_AddTemplate_Call struct {
 }
 
 // AddTemplate is a helper method to define mock.On call
-//   - _a0 string
+//   - _a0 context.Context
 //   - _a1 string
-func (_e *MockRegistry_Expecter) AddTemplate(_a0 interface{}, _a1 interface{}) *MockRegistry_AddTemplate_Call {
-	return &MockRegistry_AddTemplate_Call{Call: _e.mock.On("AddTemplate", _a0, _a1)}
+//   - _a2 string
+func (_e *MockRegistry_Expecter) AddTemplate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockRegistry_AddTemplate_Call {
+	return &MockRegistry_AddTemplate_Call{Call: _e.mock.On("AddTemplate", _a0, _a1, _a2)}
 }
 
-func (_c *MockRegistry_AddTemplate_Call) Run(run func(_a0 string, _a1 string)) *MockRegistry_AddTemplate_Call {
+func (_c *MockRegistry_AddTemplate_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockRegistry_AddTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -105,13 +114,13 @@ func (_c *MockRegistry_AddTemplate_Call) Return(_a0 error) *MockRegistry_AddTemp
 	return _c
 }
 
-// CertificatesByTemplatePK provides a mock function with given fields: _a0
-func (_m *MockRegistry) CertificatesByTemplatePK(_a0 int) ([]string, error) {
-	ret := _m.Called(_a0)
+// CertificatesByTemplatePK provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) CertificatesByTemplatePK(_a0 context.Context, _a1 int) ([]string, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 []string
-	if rf, ok := ret.Get(0).(func(int) []string); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
@@ -119,8 +128,8 @@ func (_m *MockRegistry) CertificatesByTemplatePK(_a0 int) ([]string, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,14 +143,15 @@ type MockRegistry_CertificatesByTemplatePK_Call struct {
 }
 
 // CertificatesByTemplatePK is a helper method to define mock.On call
-//   - _a0 int
-func (_e *MockRegistry_Expecter) CertificatesByTemplatePK(_a0 interface{}) *MockRegistry_CertificatesByTemplatePK_Call {
-	return &MockRegistry_CertificatesByTemplatePK_Call{Call: _e.mock.On("CertificatesByTemplatePK", _a0)}
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockRegistry_Expecter) CertificatesByTemplatePK(_a0 interface{}, _a1 interface{}) *MockRegistry_CertificatesByTemplatePK_Call {
+	return &MockRegistry_CertificatesByTemplatePK_Call{Call: _e.mock.On("CertificatesByTemplatePK", _a0, _a1)}
 }
 
-func (_c *MockRegistry_CertificatesByTemplatePK_Call) Run(run func(_a0 int)) *MockRegistry_CertificatesByTemplatePK_Call {
+func (_c *MockRegistry_CertificatesByTemplatePK_Call) Run(run func(_a0 context.Context, _a1 int)) *MockRegistry_CertificatesByTemplatePK_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
@@ -151,13 +161,13 @@ func (_c *MockRegistry_CertificatesByTemplatePK_Call) Return(_a0 []string, _a1 e
 	return _c
 }
 
-// DeleteCertificate provides a mock function with given fields: _a0
-func (_m *MockRegistry) DeleteCertificate(_a0 string) error {
-	ret := _m.Called(_a0)
+// DeleteCertificate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) DeleteCertificate(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -171,14 +181,15 @@ type MockRegistry_DeleteCertificate_Call struct {
 }
 
 // DeleteCertificate is a helper method to define mock.On call
-//   - _a0 string
-func (_e *MockRegistry_Expecter) DeleteCertificate(_a0 interface{}) *MockRegistry_DeleteCertificate_Call {
-	return &MockRegistry_DeleteCertificate_Call{Call: _e.mock.On("DeleteCertificate", _a0)}
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockRegistry_Expecter) DeleteCertificate(_a0 interface{}, _a1 interface{}) *MockRegistry_DeleteCertificate_Call {
+	return &MockRegistry_DeleteCertificate_Call{Call: _e.mock.On("DeleteCertificate", _a0, _a1)}
 }
 
-func (_c *MockRegistry_DeleteCertificate_Call) Run(run func(_a0 string)) *MockRegistry_DeleteCertificate_Call {
+func (_c *MockRegistry_DeleteCertificate_Call) Run(run func(_a0 context.Context, _a1 string)) *MockRegistry_DeleteCertificate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -188,13 +199,13 @@ func (_c *MockRegistry_DeleteCertificate_Call) Return(_a0 error) *MockRegistry_D
 	return _c
 }
 
-// DeleteTemplate provides a mock function with given fields: _a0
-func (_m *MockRegistry) DeleteTemplate(_a0 int) error {
-	ret := _m.Called(_a0)
+// DeleteTemplate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) DeleteTemplate(_a0 context.Context, _a1 int) error {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -208,14 +219,15 @@ type MockRegistry_DeleteTemplate_Call struct {
 }
 
 // DeleteTemplate is a helper method to define mock.On call
-//   - _a0 int
-func (_e *MockRegistry_Expecter) DeleteTemplate(_a0 interface{}) *MockRegistry_DeleteTemplate_Call {
-	return &MockRegistry_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", _a0)}
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockRegistry_Expecter) DeleteTemplate(_a0 interface{}, _a1 interface{}) *MockRegistry_DeleteTemplate_Call {
+	return &MockRegistry_DeleteTemplate_Call{Call: _e.mock.On("DeleteTemplate", _a0, _a1)}
 }
 
-func (_c *MockRegistry_DeleteTemplate_Call) Run(run func(_a0 int)) *MockRegistry_DeleteTemplate_Call {
+func (_c *MockRegistry_DeleteTemplate_Call) Run(run func(_a0 context.Context, _a1 int)) *MockRegistry_DeleteTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
@@ -225,13 +237,13 @@ func (_c *MockRegistry_DeleteTemplate_Call) Return(_a0 error) *MockRegistry_Dele
 	return _c
 }
 
-// GetCertificate provides a mock function with given fields: _a0
-func (_m *MockRegistry) GetCertificate(_a0 string) (*Certificate, error) {
-	ret := _m.Called(_a0)
+// GetCertificate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) GetCertificate(_a0 context.Context, _a1 string) (*Certificate, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *Certificate
-	if rf, ok := ret.Get(0).(func(string) *Certificate); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *Certificate); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*Certificate)
@@ -239,8 +251,8 @@ func (_m *MockRegistry) GetCertificate(_a0 string) (*Certificate, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -254,14 +266,15 @@ type MockRegistry_GetCertificate_Call struct {
 }
 
 // GetCertificate is a helper method to define mock.On call
-//   - _a0 string
-func (_e *MockRegistry_Expecter) GetCertificate(_a0 interface{}) *MockRegistry_GetCertificate_Call {
-	return &MockRegistry_GetCertificate_Call{Call: _e.mock.On("GetCertificate", _a0)}
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockRegistry_Expecter) GetCertificate(_a0 interface{}, _a1 interface{}) *MockRegistry_GetCertificate_Call {
+	return &MockRegistry_GetCertificate_Call{Call: _e.mock.On("GetCertificate", _a0, _a1)}
 }
 
-func (_c *MockRegistry_GetCertificate_Call) Run(run func(_a0 string)) *MockRegistry_GetCertificate_Call {
+func (_c *MockRegistry_GetCertificate_Call) Run(run func(_a0 context.Context, _a1 string)) *MockRegistry_GetCertificate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -271,13 +284,60 @@ func (_c *MockRegistry_GetCertificate_Call) Return(_a0 *Certificate, _a1 error)
 	return _c
 }
 
-// GetTemplateContent provides a mock function with given fields: _a0
-func (_m *MockRegistry) GetTemplateContent(_a0 int) (*string, error) {
-	ret := _m.Called(_a0)
+// GetTemplate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) GetTemplate(_a0 context.Context, _a1 int) (*Template, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *Template
+	if rf, ok := ret.Get(0).(func(context.Context, int) *Template); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Template)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRegistry_GetTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTemplate'
+type MockRegistry_GetTemplate_Call struct {
+	*mock.Call
+}
+
+// GetTemplate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockRegistry_Expecter) GetTemplate(_a0 interface{}, _a1 interface{}) *MockRegistry_GetTemplate_Call {
+	return &MockRegistry_GetTemplate_Call{Call: _e.mock.On("GetTemplate", _a0, _a1)}
+}
+
+func (_c *MockRegistry_GetTemplate_Call) Run(run func(_a0 context.Context, _a1 int)) *MockRegistry_GetTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_GetTemplate_Call) Return(_a0 *Template, _a1 error) *MockRegistry_GetTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetTemplateContent provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) GetTemplateContent(_a0 context.Context, _a1 int) (*string, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *string
-	if rf, ok := ret.Get(0).(func(int) *string); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *string); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*string)
@@ -285,8 +345,8 @@ func (_m *MockRegistry) GetTemplateContent(_a0 int) (*string, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -300,14 +360,15 @@ type MockRegistry_GetTemplateContent_Call struct {
 }
 
 // GetTemplateContent is a helper method to define mock.On call
-//   - _a0 int
-func (_e *MockRegistry_Expecter) GetTemplateContent(_a0 interface{}) *MockRegistry_GetTemplateContent_Call {
-	return &MockRegistry_GetTemplateContent_Call{Call: _e.mock.On("GetTemplateContent", _a0)}
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockRegistry_Expecter) GetTemplateContent(_a0 interface{}, _a1 interface{}) *MockRegistry_GetTemplateContent_Call {
+	return &MockRegistry_GetTemplateContent_Call{Call: _e.mock.On("GetTemplateContent", _a0, _a1)}
 }
 
-func (_c *MockRegistry_GetTemplateContent_Call) Run(run func(_a0 int)) *MockRegistry_GetTemplateContent_Call {
+func (_c *MockRegistry_GetTemplateContent_Call) Run(run func(_a0 context.Context, _a1 int)) *MockRegistry_GetTemplateContent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
@@ -317,20 +378,20 @@ func (_c *MockRegistry_GetTemplateContent_Call) Return(_a0 *string, _a1 error) *
 	return _c
 }
 
-// GetTemplatePK provides a mock function with given fields: _a0
-func (_m *MockRegistry) GetTemplatePK(_a0 string) (int, error) {
-	ret := _m.Called(_a0)
+// GetTemplatePK provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) GetTemplatePK(_a0 context.Context, _a1 string) (int, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -344,14 +405,15 @@ type MockRegistry_GetTemplatePK_Call struct {
 }
 
 // GetTemplatePK is a helper method to define mock.On call
-//   - _a0 string
-func (_e *MockRegistry_Expecter) GetTemplatePK(_a0 interface{}) *MockRegistry_GetTemplatePK_Call {
-	return &MockRegistry_GetTemplatePK_Call{Call: _e.mock.On("GetTemplatePK", _a0)}
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockRegistry_Expecter) GetTemplatePK(_a0 interface{}, _a1 interface{}) *MockRegistry_GetTemplatePK_Call {
+	return &MockRegistry_GetTemplatePK_Call{Call: _e.mock.On("GetTemplatePK", _a0, _a1)}
 }
 
-func (_c *MockRegistry_GetTemplatePK_Call) Run(run func(_a0 string)) *MockRegistry_GetTemplatePK_Call {
+func (_c *MockRegistry_GetTemplatePK_Call) Run(run func(_a0 context.Context, _a1 string)) *MockRegistry_GetTemplatePK_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -361,13 +423,107 @@ func (_c *MockRegistry_GetTemplatePK_Call) Return(_a0 int, _a1 error) *MockRegis
 	return _c
 }
 
-// ListTemplates provides a mock function with given fields:
-func (_m *MockRegistry) ListTemplates() ([]string, error) {
-	ret := _m.Called()
+// ListAuditEvents provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) ListAuditEvents(_a0 context.Context, _a1 AuditFilter) ([]AuditEvent, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, AuditFilter) []AuditEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, AuditFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRegistry_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type MockRegistry_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 AuditFilter
+func (_e *MockRegistry_Expecter) ListAuditEvents(_a0 interface{}, _a1 interface{}) *MockRegistry_ListAuditEvents_Call {
+	return &MockRegistry_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", _a0, _a1)}
+}
+
+func (_c *MockRegistry_ListAuditEvents_Call) Run(run func(_a0 context.Context, _a1 AuditFilter)) *MockRegistry_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(AuditFilter))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_ListAuditEvents_Call) Return(_a0 []AuditEvent, _a1 error) *MockRegistry_ListAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListExpiringCertificates provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) ListExpiringCertificates(_a0 context.Context, _a1 time.Duration) ([]*Certificate, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*Certificate
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []*Certificate); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Certificate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRegistry_ListExpiringCertificates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiringCertificates'
+type MockRegistry_ListExpiringCertificates_Call struct {
+	*mock.Call
+}
+
+// ListExpiringCertificates is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Duration
+func (_e *MockRegistry_Expecter) ListExpiringCertificates(_a0 interface{}, _a1 interface{}) *MockRegistry_ListExpiringCertificates_Call {
+	return &MockRegistry_ListExpiringCertificates_Call{Call: _e.mock.On("ListExpiringCertificates", _a0, _a1)}
+}
+
+func (_c *MockRegistry_ListExpiringCertificates_Call) Run(run func(_a0 context.Context, _a1 time.Duration)) *MockRegistry_ListExpiringCertificates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_ListExpiringCertificates_Call) Return(_a0 []*Certificate, _a1 error) *MockRegistry_ListExpiringCertificates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListTemplates provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) ListTemplates(_a0 context.Context, _a1 bool) ([]string, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 []string
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []string); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
@@ -375,8 +531,8 @@ func (_m *MockRegistry) ListTemplates() ([]string, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -390,13 +546,15 @@ type MockRegistry_ListTemplates_Call struct {
 }
 
 // ListTemplates is a helper method to define mock.On call
-func (_e *MockRegistry_Expecter) ListTemplates() *MockRegistry_ListTemplates_Call {
-	return &MockRegistry_ListTemplates_Call{Call: _e.mock.On("ListTemplates")}
+//   - _a0 context.Context
+//   - _a1 bool
+func (_e *MockRegistry_Expecter) ListTemplates(_a0 interface{}, _a1 interface{}) *MockRegistry_ListTemplates_Call {
+	return &MockRegistry_ListTemplates_Call{Call: _e.mock.On("ListTemplates", _a0, _a1)}
 }
 
-func (_c *MockRegistry_ListTemplates_Call) Run(run func()) *MockRegistry_ListTemplates_Call {
+func (_c *MockRegistry_ListTemplates_Call) Run(run func(_a0 context.Context, _a1 bool)) *MockRegistry_ListTemplates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(bool))
 	})
 	return _c
 }
@@ -406,12 +564,57 @@ func (_c *MockRegistry_ListTemplates_Call) Return(_a0 []string, _a1 error) *Mock
 	return _c
 }
 
-// UpdateCertificate provides a mock function with given fields: _a0, _a1
-func (_m *MockRegistry) UpdateCertificate(_a0 string, _a1 map[string]string) error {
+// PurgeDeleted provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) PurgeDeleted(_a0 context.Context, _a1 time.Time) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRegistry_PurgeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeleted'
+type MockRegistry_PurgeDeleted_Call struct {
+	*mock.Call
+}
+
+// PurgeDeleted is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+func (_e *MockRegistry_Expecter) PurgeDeleted(_a0 interface{}, _a1 interface{}) *MockRegistry_PurgeDeleted_Call {
+	return &MockRegistry_PurgeDeleted_Call{Call: _e.mock.On("PurgeDeleted", _a0, _a1)}
+}
+
+func (_c *MockRegistry_PurgeDeleted_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockRegistry_PurgeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_PurgeDeleted_Call) Return(_a0 int, _a1 error) *MockRegistry_PurgeDeleted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RestoreCertificate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) RestoreCertificate(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -420,36 +623,36 @@ func (_m *MockRegistry) UpdateCertificate(_a0 string, _a1 map[string]string) err
 	return r0
 }
 
-// MockRegistry_UpdateCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCertificate'
-type MockRegistry_UpdateCertificate_Call struct {
+// MockRegistry_RestoreCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCertificate'
+type MockRegistry_RestoreCertificate_Call struct {
 	*mock.Call
 }
 
-// UpdateCertificate is a helper method to define mock.On call
-//   - _a0 string
-//   - _a1 map[string]string
-func (_e *MockRegistry_Expecter) UpdateCertificate(_a0 interface{}, _a1 interface{}) *MockRegistry_UpdateCertificate_Call {
-	return &MockRegistry_UpdateCertificate_Call{Call: _e.mock.On("UpdateCertificate", _a0, _a1)}
+// RestoreCertificate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockRegistry_Expecter) RestoreCertificate(_a0 interface{}, _a1 interface{}) *MockRegistry_RestoreCertificate_Call {
+	return &MockRegistry_RestoreCertificate_Call{Call: _e.mock.On("RestoreCertificate", _a0, _a1)}
 }
 
-func (_c *MockRegistry_UpdateCertificate_Call) Run(run func(_a0 string, _a1 map[string]string)) *MockRegistry_UpdateCertificate_Call {
+func (_c *MockRegistry_RestoreCertificate_Call) Run(run func(_a0 context.Context, _a1 string)) *MockRegistry_RestoreCertificate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(map[string]string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockRegistry_UpdateCertificate_Call) Return(_a0 error) *MockRegistry_UpdateCertificate_Call {
+func (_c *MockRegistry_RestoreCertificate_Call) Return(_a0 error) *MockRegistry_RestoreCertificate_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-// UpdateTemplate provides a mock function with given fields: _a0, _a1
-func (_m *MockRegistry) UpdateTemplate(_a0 int, _a1 map[string]string) error {
+// RestoreTemplate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) RestoreTemplate(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, map[string]string) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -458,21 +661,183 @@ func (_m *MockRegistry) UpdateTemplate(_a0 int, _a1 map[string]string) error {
 	return r0
 }
 
+// MockRegistry_RestoreTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTemplate'
+type MockRegistry_RestoreTemplate_Call struct {
+	*mock.Call
+}
+
+// RestoreTemplate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockRegistry_Expecter) RestoreTemplate(_a0 interface{}, _a1 interface{}) *MockRegistry_RestoreTemplate_Call {
+	return &MockRegistry_RestoreTemplate_Call{Call: _e.mock.On("RestoreTemplate", _a0, _a1)}
+}
+
+func (_c *MockRegistry_RestoreTemplate_Call) Run(run func(_a0 context.Context, _a1 string)) *MockRegistry_RestoreTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_RestoreTemplate_Call) Return(_a0 error) *MockRegistry_RestoreTemplate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// RevokeCertificate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRegistry) RevokeCertificate(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRegistry_RevokeCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeCertificate'
+type MockRegistry_RevokeCertificate_Call struct {
+	*mock.Call
+}
+
+// RevokeCertificate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockRegistry_Expecter) RevokeCertificate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockRegistry_RevokeCertificate_Call {
+	return &MockRegistry_RevokeCertificate_Call{Call: _e.mock.On("RevokeCertificate", _a0, _a1, _a2)}
+}
+
+func (_c *MockRegistry_RevokeCertificate_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockRegistry_RevokeCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_RevokeCertificate_Call) Return(_a0 error) *MockRegistry_RevokeCertificate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// UnrevokeCertificate provides a mock function with given fields: _a0, _a1
+func (_m *MockRegistry) UnrevokeCertificate(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRegistry_UnrevokeCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnrevokeCertificate'
+type MockRegistry_UnrevokeCertificate_Call struct {
+	*mock.Call
+}
+
+// UnrevokeCertificate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockRegistry_Expecter) UnrevokeCertificate(_a0 interface{}, _a1 interface{}) *MockRegistry_UnrevokeCertificate_Call {
+	return &MockRegistry_UnrevokeCertificate_Call{Call: _e.mock.On("UnrevokeCertificate", _a0, _a1)}
+}
+
+func (_c *MockRegistry_UnrevokeCertificate_Call) Run(run func(_a0 context.Context, _a1 string)) *MockRegistry_UnrevokeCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_UnrevokeCertificate_Call) Return(_a0 error) *MockRegistry_UnrevokeCertificate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// UpdateCertificate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRegistry) UpdateCertificate(_a0 context.Context, _a1 string, _a2 CertificateUpdate) (time.Time, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, string, CertificateUpdate) time.Time); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, CertificateUpdate) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRegistry_UpdateCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCertificate'
+type MockRegistry_UpdateCertificate_Call struct {
+	*mock.Call
+}
+
+// UpdateCertificate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 CertificateUpdate
+func (_e *MockRegistry_Expecter) UpdateCertificate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockRegistry_UpdateCertificate_Call {
+	return &MockRegistry_UpdateCertificate_Call{Call: _e.mock.On("UpdateCertificate", _a0, _a1, _a2)}
+}
+
+func (_c *MockRegistry_UpdateCertificate_Call) Run(run func(_a0 context.Context, _a1 string, _a2 CertificateUpdate)) *MockRegistry_UpdateCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(CertificateUpdate))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_UpdateCertificate_Call) Return(_a0 time.Time, _a1 error) *MockRegistry_UpdateCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// UpdateTemplate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockRegistry) UpdateTemplate(_a0 context.Context, _a1 int, _a2 TemplateUpdate) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, TemplateUpdate) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockRegistry_UpdateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplate'
 type MockRegistry_UpdateTemplate_Call struct {
 	*mock.Call
 }
 
 // UpdateTemplate is a helper method to define mock.On call
-//   - _a0 int
-//   - _a1 map[string]string
-func (_e *MockRegistry_Expecter) UpdateTemplate(_a0 interface{}, _a1 interface{}) *MockRegistry_UpdateTemplate_Call {
-	return &MockRegistry_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", _a0, _a1)}
+//   - _a0 context.Context
+//   - _a1 int
+//   - _a2 TemplateUpdate
+func (_e *MockRegistry_Expecter) UpdateTemplate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockRegistry_UpdateTemplate_Call {
+	return &MockRegistry_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", _a0, _a1, _a2)}
 }
 
-func (_c *MockRegistry_UpdateTemplate_Call) Run(run func(_a0 int, _a1 map[string]string)) *MockRegistry_UpdateTemplate_Call {
+func (_c *MockRegistry_UpdateTemplate_Call) Run(run func(_a0 context.Context, _a1 int, _a2 TemplateUpdate)) *MockRegistry_UpdateTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(map[string]string))
+		run(args[0].(context.Context), args[1].(int), args[2].(TemplateUpdate))
 	})
 	return _c
 }
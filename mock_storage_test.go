@@ -3,6 +3,7 @@
 package golangunitedschoolcerts
 
 import (
+	io "io"
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
@@ -60,6 +61,51 @@ func (_c *MockStorage_Add_Call) Return(_a0 error) *MockStorage_Add_Call {
 	return _c
 }
 
+// Checksum provides a mock function with given fields: _a0, _a1
+func (_m *MockStorage) Checksum(_a0 string, _a1 time.Time) (string, bool) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Time) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string, time.Time) bool); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_Checksum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Checksum'
+type MockStorage_Checksum_Call struct {
+	*mock.Call
+}
+
+// Checksum is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 time.Time
+func (_e *MockStorage_Expecter) Checksum(_a0 interface{}, _a1 interface{}) *MockStorage_Checksum_Call {
+	return &MockStorage_Checksum_Call{Call: _e.mock.On("Checksum", _a0, _a1)}
+}
+
+func (_c *MockStorage_Checksum_Call) Run(run func(_a0 string, _a1 time.Time)) *MockStorage_Checksum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStorage_Checksum_Call) Return(_a0 string, _a1 bool) *MockStorage_Checksum_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Contains provides a mock function with given fields: _a0, _a1
 func (_m *MockStorage) Contains(_a0 string, _a1 time.Time) bool {
 	ret := _m.Called(_a0, _a1)
@@ -210,6 +256,53 @@ func (_c *MockStorage_Load_Call) Return(_a0 error) *MockStorage_Load_Call {
 	return _c
 }
 
+// Open provides a mock function with given fields: _a0, _a1
+func (_m *MockStorage) Open(_a0 string, _a1 time.Time) (io.ReadCloser, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(string, time.Time) io.ReadCloser); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockStorage_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 time.Time
+func (_e *MockStorage_Expecter) Open(_a0 interface{}, _a1 interface{}) *MockStorage_Open_Call {
+	return &MockStorage_Open_Call{Call: _e.mock.On("Open", _a0, _a1)}
+}
+
+func (_c *MockStorage_Open_Call) Run(run func(_a0 string, _a1 time.Time)) *MockStorage_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStorage_Open_Call) Return(_a0 io.ReadCloser, _a1 error) *MockStorage_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 type mockConstructorTestingTNewMockStorage interface {
 	mock.TestingT
 	Cleanup(func())
@@ -17,13 +17,13 @@ func (_m *MockTemplater) EXPECT() *MockTemplater_Expecter {
 	return &MockTemplater_Expecter{mock: &_m.Mock}
 }
 
-// GenerateCertificate provides a mock function with given fields: template, certificate, link
-func (_m *MockTemplater) GenerateCertificate(template string, certificate *Certificate, link string) (*[]byte, error) {
-	ret := _m.Called(template, certificate, link)
+// GenerateCertificate provides a mock function with given fields: template, certificate, link, variant
+func (_m *MockTemplater) GenerateCertificate(template string, certificate *Certificate, link string, variant Variant) (*[]byte, error) {
+	ret := _m.Called(template, certificate, link, variant)
 
 	var r0 *[]byte
-	if rf, ok := ret.Get(0).(func(string, *Certificate, string) *[]byte); ok {
-		r0 = rf(template, certificate, link)
+	if rf, ok := ret.Get(0).(func(string, *Certificate, string, Variant) *[]byte); ok {
+		r0 = rf(template, certificate, link, variant)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]byte)
@@ -31,8 +31,8 @@ func (_m *MockTemplater) GenerateCertificate(template string, certificate *Certi
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, *Certificate, string) error); ok {
-		r1 = rf(template, certificate, link)
+	if rf, ok := ret.Get(1).(func(string, *Certificate, string, Variant) error); ok {
+		r1 = rf(template, certificate, link, variant)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -49,13 +49,14 @@ type MockTemplater_GenerateCertificate_Call struct {
 //   - template string
 //   - certificate *Certificate
 //   - link string
-func (_e *MockTemplater_Expecter) GenerateCertificate(template interface{}, certificate interface{}, link interface{}) *MockTemplater_GenerateCertificate_Call {
-	return &MockTemplater_GenerateCertificate_Call{Call: _e.mock.On("GenerateCertificate", template, certificate, link)}
+//   - variant Variant
+func (_e *MockTemplater_Expecter) GenerateCertificate(template interface{}, certificate interface{}, link interface{}, variant interface{}) *MockTemplater_GenerateCertificate_Call {
+	return &MockTemplater_GenerateCertificate_Call{Call: _e.mock.On("GenerateCertificate", template, certificate, link, variant)}
 }
 
-func (_c *MockTemplater_GenerateCertificate_Call) Run(run func(template string, certificate *Certificate, link string)) *MockTemplater_GenerateCertificate_Call {
+func (_c *MockTemplater_GenerateCertificate_Call) Run(run func(template string, certificate *Certificate, link string, variant Variant)) *MockTemplater_GenerateCertificate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*Certificate), args[2].(string))
+		run(args[0].(string), args[1].(*Certificate), args[2].(string), args[3].(Variant))
 	})
 	return _c
 }
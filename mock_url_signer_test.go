@@ -0,0 +1,83 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package golangunitedschoolcerts
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockURLSigner is an autogenerated mock type for the URLSigner type
+type MockURLSigner struct {
+	mock.Mock
+}
+
+type MockURLSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockURLSigner) EXPECT() *MockURLSigner_Expecter {
+	return &MockURLSigner_Expecter{mock: &_m.Mock}
+}
+
+// SignedURL provides a mock function with given fields: id, timestamp, ttl
+func (_m *MockURLSigner) SignedURL(id string, timestamp time.Time, ttl time.Duration) (string, error) {
+	ret := _m.Called(id, timestamp, ttl)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Duration) string); ok {
+		r0 = rf(id, timestamp, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Duration) error); ok {
+		r1 = rf(id, timestamp, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLSigner_SignedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignedURL'
+type MockURLSigner_SignedURL_Call struct {
+	*mock.Call
+}
+
+// SignedURL is a helper method to define mock.On call
+//   - id string
+//   - timestamp time.Time
+//   - ttl time.Duration
+func (_e *MockURLSigner_Expecter) SignedURL(id interface{}, timestamp interface{}, ttl interface{}) *MockURLSigner_SignedURL_Call {
+	return &MockURLSigner_SignedURL_Call{Call: _e.mock.On("SignedURL", id, timestamp, ttl)}
+}
+
+func (_c *MockURLSigner_SignedURL_Call) Run(run func(id string, timestamp time.Time, ttl time.Duration)) *MockURLSigner_SignedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockURLSigner_SignedURL_Call) Return(_a0 string, _a1 error) *MockURLSigner_SignedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewMockURLSigner interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockURLSigner creates a new instance of MockURLSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockURLSigner(t mockConstructorTestingTNewMockURLSigner) *MockURLSigner {
+	mock := &MockURLSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
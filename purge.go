@@ -0,0 +1,51 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Actor recorded in audit log for rows purged by PurgeJob
+const PurgeActor = "purge-job"
+
+// Periodically hard-deletes templates and certificates soft-deleted longer than retention ago
+type PurgeJob struct {
+	r         Registry
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func NewPurgeJob(r Registry, retention time.Duration, interval time.Duration) (*PurgeJob, error) {
+	if retention <= 0 {
+		return nil, fmt.Errorf("purge retention must be positive, got %v", retention)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("purge interval must be positive, got %v", interval)
+	}
+	return &PurgeJob{r: r, retention: retention, interval: interval, now: time.Now}, nil
+}
+
+// Purges once right away and then every interval, until ctx is done
+func (j *PurgeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if n, err := j.Purge(ctx); err != nil {
+			log.Printf("Failed to purge deleted rows: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d deleted rows", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *PurgeJob) Purge(ctx context.Context) (int, error) {
+	return j.r.PurgeDeleted(WithActor(ctx, PurgeActor), j.now().Add(-j.retention))
+}
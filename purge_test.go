@@ -0,0 +1,74 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewPurgeJob(t *testing.T) {
+	rMock := NewMockRegistry(t)
+	t.Run("Positive durations", func(t *testing.T) {
+		j, err := NewPurgeJob(rMock, time.Hour, time.Minute)
+		assert.NoError(t, err)
+		assert.NotNil(t, j)
+	})
+	t.Run("Zero retention", func(t *testing.T) {
+		_, err := NewPurgeJob(rMock, 0, time.Minute)
+		assert.ErrorContains(t, err, "retention must be positive")
+	})
+	t.Run("Negative interval", func(t *testing.T) {
+		_, err := NewPurgeJob(rMock, time.Hour, -time.Minute)
+		assert.ErrorContains(t, err, "interval must be positive")
+	})
+}
+
+func Test_PurgeJob_Purge(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	retention := 24 * time.Hour
+	isPurgeActor := mock.MatchedBy(func(ctx context.Context) bool {
+		return ActorFromContext(ctx) == PurgeActor
+	})
+	t.Run("Purges rows deleted before retention period", func(t *testing.T) {
+		rMock := NewMockRegistry(t)
+		j, _ := NewPurgeJob(rMock, retention, time.Hour)
+		j.now = func() time.Time { return now }
+		rMock.EXPECT().PurgeDeleted(isPurgeActor, now.Add(-retention)).Return(3, nil)
+		n, err := j.Purge(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		rMock := NewMockRegistry(t)
+		j, _ := NewPurgeJob(rMock, retention, time.Hour)
+		j.now = func() time.Time { return now }
+		rMock.EXPECT().PurgeDeleted(isPurgeActor, now.Add(-retention)).Return(0, fmt.Errorf("PurgeDeleted error"))
+		_, err := j.Purge(context.Background())
+		assert.ErrorContains(t, err, "PurgeDeleted error")
+	})
+}
+
+func Test_PurgeJob_Run(t *testing.T) {
+	t.Run("Purges immediately and stops when context is done", func(t *testing.T) {
+		rMock := NewMockRegistry(t)
+		j, _ := NewPurgeJob(rMock, time.Hour, time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		rMock.EXPECT().PurgeDeleted(mock.Anything, mock.Anything).
+			Run(func(context.Context, time.Time) { cancel() }).
+			Return(0, nil).Once()
+		done := make(chan struct{})
+		go func() {
+			j.Run(ctx)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			assert.FailNow(t, "PurgeJob did not stop after context was canceled")
+		}
+	})
+}
@@ -0,0 +1,316 @@
+package golangunitedschoolcerts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	tmpl "html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"strconv"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Options of QR codes rendered by qr template function
+type qrOptions struct {
+	level qrcode.RecoveryLevel
+	// Foreground and background colours, background may be transparent
+	fg, bg color.NRGBA
+	// Width of quiet zone in modules
+	border int
+	svg    bool
+	// Data URI of image put into centre of code
+	logo string
+}
+
+// Share of code width covered by logo, small enough for High recovery level to restore hidden modules
+const qrLogoShare = 0.22
+
+// Largest width of code in pixels, bigger codes would take too much memory to render
+const qrMaxSize = 4096
+
+var qrLevels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// Template function rendering QR code of content as data URI, e.g. {{ qr .Link 256 "fg" "#00add8" "format" "svg" }}.
+// Size is width in pixels, negative size is width of single module. Options are pairs of name and value:
+// "level" (L, M, Q or H), "fg" and "bg" (CSS hex colour or transparent), "border" (quiet zone in modules),
+// "format" (png or svg) and "logo" (data URI of image put into centre).
+func qrFunc(content string, size int, options ...any) (tmpl.URL, error) {
+	o, err := parseQROptions(options)
+	if err != nil {
+		return "", err
+	}
+	b, err := renderQR(content, size, o)
+	if err != nil {
+		return "", err
+	}
+	mime := "image/png"
+	if o.svg {
+		mime = "image/svg+xml"
+	}
+	return tmpl.URL("data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// Renders QR code of content as PNG or SVG image of size, see qrFunc
+func renderQR(content string, size int, o *qrOptions) ([]byte, error) {
+	if size == 0 {
+		return nil, fmt.Errorf("QR code size must not be zero")
+	}
+	if size > qrMaxSize || size < -qrMaxSize {
+		return nil, fmt.Errorf("QR code size %d exceeds limit of %d pixels", size, qrMaxSize)
+	}
+	q, err := qrcode.New(content, o.level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	q.DisableBorder = true
+	bitmap := q.Bitmap()
+	if modules, pixels := qrScale(bitmap, size, o.border); size < 0 && modules*pixels > qrMaxSize {
+		return nil, fmt.Errorf("QR code of %d modules %d pixels each exceeds limit of %d pixels", modules, pixels, qrMaxSize)
+	}
+	if o.svg {
+		return qrSVG(bitmap, size, o), nil
+	}
+	return qrPNG(bitmap, size, o)
+}
+
+// Options of qr template function unless they are given
+func defaultQROptions() *qrOptions {
+	return &qrOptions{
+		level:  qrcode.High,
+		fg:     color.NRGBA{A: 0xff},
+		bg:     color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		border: 4,
+	}
+}
+
+func parseQROptions(options []any) (*qrOptions, error) {
+	if len(options)%2 != 0 {
+		return nil, fmt.Errorf("QR code options must be pairs of name and value")
+	}
+	o := defaultQROptions()
+	levelSet := false
+	for i := 0; i < len(options); i += 2 {
+		name, value := fmt.Sprint(options[i]), fmt.Sprint(options[i+1])
+		var err error
+		switch name {
+		case "level":
+			l, ok := qrLevels[strings.ToUpper(value)]
+			if !ok {
+				return nil, fmt.Errorf("unknown QR code recovery level %q, expected L, M, Q or H", value)
+			}
+			o.level, levelSet = l, true
+		case "fg":
+			o.fg, err = parseColor(value)
+		case "bg":
+			o.bg, err = parseColor(value)
+		case "border":
+			o.border, err = strconv.Atoi(value)
+			if err == nil && o.border < 0 {
+				err = fmt.Errorf("QR code border must not be negative")
+			}
+		case "format":
+			switch value {
+			case "png":
+				o.svg = false
+			case "svg":
+				o.svg = true
+			default:
+				return nil, fmt.Errorf("unknown QR code format %q, expected png or svg", value)
+			}
+		case "logo":
+			if !strings.HasPrefix(value, "data:image/") {
+				return nil, fmt.Errorf("QR code logo must be data URI of image")
+			}
+			o.logo = value
+		default:
+			return nil, fmt.Errorf("unknown QR code option %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if o.logo != "" {
+		if !levelSet {
+			o.level = qrcode.Highest
+		} else if o.level < qrcode.High {
+			return nil, fmt.Errorf("QR code with logo requires recovery level Q or H")
+		}
+	}
+	return o, nil
+}
+
+// Parses CSS hex colour or transparent
+func parseColor(s string) (color.NRGBA, error) {
+	if s == "transparent" {
+		return color.NRGBA{}, nil
+	}
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 || len(hex) == 4 {
+		long := make([]byte, 0, 2*len(hex))
+		for i := range hex {
+			long = append(long, hex[i], hex[i])
+		}
+		hex = string(long)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if !strings.HasPrefix(s, "#") || len(hex) != 8 || err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid colour %q, expected #rgb, #rgba, #rrggbb, #rrggbbaa or transparent", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// Modules per side including quiet zone and pixels per module for requested size.
+// Like go-qrcode, positive size is rounded down to whole pixels per module and code is centred.
+func qrScale(bitmap [][]bool, size int, border int) (modules, pixels int) {
+	modules = len(bitmap) + 2*border
+	if size < 0 {
+		return modules, -size
+	}
+	return modules, size / modules
+}
+
+// Square area of logo in the centre of code, in units of its side
+func qrLogoBox(side float64) (offset, width float64) {
+	width = side * qrLogoShare
+	return (side - width) / 2, width
+}
+
+func qrPNG(bitmap [][]bool, size int, o *qrOptions) ([]byte, error) {
+	modules, pixels := qrScale(bitmap, size, o.border)
+	if pixels < 1 {
+		return nil, fmt.Errorf("QR code of %d modules does not fit into %d pixels", modules, size)
+	}
+	width := modules * pixels
+	if size > 0 {
+		width = size
+	}
+	offset := (width - modules*pixels) / 2
+	img := image.NewNRGBA(image.Rect(0, 0, width, width))
+	draw.Draw(img, img.Bounds(), &image.Uniform{o.bg}, image.Point{}, draw.Src)
+	fg := &image.Uniform{o.fg}
+	for y, row := range bitmap {
+		for x, on := range row {
+			if on {
+				x0, y0 := offset+(x+o.border)*pixels, offset+(y+o.border)*pixels
+				draw.Draw(img, image.Rect(x0, y0, x0+pixels, y0+pixels), fg, image.Point{}, draw.Src)
+			}
+		}
+	}
+	if o.logo != "" {
+		if err := drawQRLogo(img, o, float64(offset+o.border*pixels), float64(len(bitmap)*pixels), float64(pixels)); err != nil {
+			return nil, err
+		}
+	}
+	b := bytes.Buffer{}
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code image: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// Draws logo over modules of code starting at start pixel and side pixels wide, keeping its aspect ratio.
+// Logo is scaled with nearest neighbour, SVG format keeps it sharp.
+func drawQRLogo(img draw.Image, o *qrOptions, start, side, pixels float64) error {
+	i := strings.Index(o.logo, ";base64,")
+	if i < 0 {
+		return fmt.Errorf("QR code logo must be base64 encoded")
+	}
+	if strings.HasPrefix(o.logo, "data:image/svg") {
+		return fmt.Errorf("SVG logo requires svg format of QR code")
+	}
+	raw, err := base64.StdEncoding.DecodeString(o.logo[i+len(";base64,"):])
+	if err != nil {
+		return fmt.Errorf("failed to decode QR code logo: %w", err)
+	}
+	logo, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to decode QR code logo: %w", err)
+	}
+	offset, width := qrLogoBox(side)
+	// Background under logo, one module wider, separates it from modules
+	pad := image.Rect(int(start+offset-pixels), int(start+offset-pixels), int(start+offset+width+pixels), int(start+offset+width+pixels))
+	draw.Draw(img, pad, &image.Uniform{o.bg}, image.Point{}, draw.Src)
+
+	lb := logo.Bounds()
+	scale := width / float64(lb.Dx())
+	if s := width / float64(lb.Dy()); s < scale {
+		scale = s
+	}
+	w, h := int(float64(lb.Dx())*scale), int(float64(lb.Dy())*scale)
+	if w < 1 || h < 1 {
+		return nil
+	}
+	scaled := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			scaled.Set(x, y, logo.At(lb.Min.X+int(float64(x)/scale), lb.Min.Y+int(float64(y)/scale)))
+		}
+	}
+	x0 := int(start+offset) + (int(width)-w)/2
+	y0 := int(start+offset) + (int(width)-h)/2
+	draw.Draw(img, image.Rect(x0, y0, x0+w, y0+h), scaled, image.Point{}, draw.Over)
+	return nil
+}
+
+// SVG is drawn in units of modules, so it stays sharp at any print size
+func qrSVG(bitmap [][]bool, size int, o *qrOptions) []byte {
+	modules, pixels := qrScale(bitmap, size, o.border)
+	width := size
+	if size < 0 {
+		width = modules * pixels
+	}
+	b := bytes.Buffer{}
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		width, width, modules, modules)
+	if o.bg.A != 0 {
+		fmt.Fprintf(&b, `<rect width="%d" height="%d"%s/>`, modules, modules, svgFill(o.bg))
+	}
+	// Modules under logo and its one module wide background are left out
+	side := float64(len(bitmap))
+	offset, logoWidth := qrLogoBox(side)
+	covered := func(x, y int) bool {
+		if o.logo == "" {
+			return false
+		}
+		in := func(v int) bool { return float64(v+1) > offset-1 && float64(v) < offset+logoWidth+1 }
+		return in(x) && in(y)
+	}
+	fmt.Fprintf(&b, `<path%s d="`, svgFill(o.fg))
+	for y, row := range bitmap {
+		for x, on := range row {
+			if on && !covered(x, y) {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+o.border, y+o.border)
+			}
+		}
+	}
+	b.WriteString(`"/>`)
+	if o.logo != "" {
+		start := float64(o.border) + offset
+		fmt.Fprintf(&b, `<image x="%g" y="%g" width="%g" height="%g" href="%s"/>`,
+			start, start, logoWidth, logoWidth, tmpl.HTMLEscapeString(o.logo))
+	}
+	b.WriteString("</svg>")
+	return b.Bytes()
+}
+
+func svgFill(c color.NRGBA) string {
+	fill := fmt.Sprintf(` fill="#%02x%02x%02x"`, c.R, c.G, c.B)
+	if c.A != 0xff {
+		fill += fmt.Sprintf(` fill-opacity="%g"`, float64(c.A)/0xff)
+	}
+	return fill
+}
@@ -0,0 +1,155 @@
+package golangunitedschoolcerts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	qrdecode "github.com/tuotoo/qrcode"
+)
+
+const qrTestLink = "https://example.com/certificate/6acyxaxb"
+
+// Decodes base64 data URI with prefix
+func decodeTestDataURI(t *testing.T, uri string, prefix string) []byte {
+	if !assert.True(t, strings.HasPrefix(uri, prefix), "unexpected data URI %.40s", uri) {
+		t.FailNow()
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(uri, prefix))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return b
+}
+
+// Light logo is read as light modules by decoder, so it checks that hidden modules are recovered
+func testLogo(t *testing.T) string {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.NRGBA{R: 0xff, G: 0xd5, B: 0x4f, A: 0xff})
+		}
+	}
+	b := bytes.Buffer{}
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(b.Bytes())
+}
+
+func Test_qrFunc(t *testing.T) {
+	logo := testLogo(t)
+
+	pngCases := map[string]struct {
+		size    int
+		options []any
+		width   int
+	}{
+		"Defaults":                {256, nil, 256},
+		"Pixels per module":       {-5, nil, (33 + 8) * 5},
+		"Brand colours":           {300, []any{"fg", "#1a237e", "bg", "#fff8e1"}, 300},
+		"Small quiet zone":        {200, []any{"border", 1, "level", "m"}, 200},
+		"Logo":                    {400, []any{"logo", logo}, 400},
+		"Logo with level Q":       {400, []any{"logo", logo, "level", "Q"}, 400},
+		"Transparent background":  {256, []any{"bg", "transparent", "format", "png"}, 256},
+		"Short colour with alpha": {256, []any{"fg", "#000f"}, 256},
+	}
+	for name, tc := range pngCases {
+		t.Run(name, func(t *testing.T) {
+			uri, err := qrFunc(qrTestLink, tc.size, tc.options...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			b := decodeTestDataURI(t, string(uri), "data:image/png;base64,")
+			cfg, err := png.DecodeConfig(bytes.NewReader(b))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.width, cfg.Width)
+				assert.Equal(t, tc.width, cfg.Height)
+			}
+			if name == "Transparent background" {
+				// decoder reads transparent pixels as black
+				return
+			}
+			m, err := qrdecode.Decode(bytes.NewReader(b))
+			if assert.NoError(t, err) {
+				assert.Equal(t, qrTestLink, m.Content)
+			}
+		})
+	}
+
+	t.Run("SVG", func(t *testing.T) {
+		uri, err := qrFunc(qrTestLink, 256, "format", "svg", "fg", "#00add8", "bg", "#ffffff80", "border", 2)
+		if !assert.NoError(t, err) {
+			return
+		}
+		svg := string(decodeTestDataURI(t, string(uri), "data:image/svg+xml;base64,"))
+		assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="256" height="256" viewBox="0 0 37 37"`), svg)
+		assert.Contains(t, svg, `<rect width="37" height="37" fill="#ffffff" fill-opacity="0.5019607843137255"/>`)
+		assert.Contains(t, svg, `<path fill="#00add8" d="M2 2h1v1h-1z`)
+		assert.True(t, strings.HasSuffix(svg, "</svg>"))
+	})
+
+	t.Run("SVG with logo and transparent background", func(t *testing.T) {
+		svgLogo := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`))
+		uri, err := qrFunc(qrTestLink, -4, "format", "svg", "bg", "transparent", "logo", svgLogo)
+		if !assert.NoError(t, err) {
+			return
+		}
+		svg := string(decodeTestDataURI(t, string(uri), "data:image/svg+xml;base64,"))
+		// level H makes version 5 code of 37 modules
+		assert.Contains(t, svg, `width="180" height="180" viewBox="0 0 45 45"`)
+		assert.NotContains(t, svg, "<rect")
+		assert.Contains(t, svg, `<image x="18.43" y="18.43" width="8.14" height="8.14" href="`+svgLogo+`"/>`)
+		// modules under logo are left out
+		assert.NotContains(t, svg, "M22 22h1v1h-1z")
+		assert.Contains(t, svg, "M4 4h1v1h-1z")
+	})
+
+	errCases := map[string]struct {
+		size    int
+		options []any
+		err     string
+	}{
+		"Zero size":           {0, nil, "must not be zero"},
+		"Too small":           {20, nil, "does not fit into 20 pixels"},
+		"Too big":             {100000, nil, "exceeds limit of 4096 pixels"},
+		"Too big modules":     {-100, nil, "exceeds limit of 4096 pixels"},
+		"Huge modules":        {-100000, nil, "exceeds limit of 4096 pixels"},
+		"Odd options":         {256, []any{"fg"}, "pairs of name and value"},
+		"Unknown option":      {256, []any{"colour", "#000"}, `unknown QR code option "colour"`},
+		"Unknown level":       {256, []any{"level", "X"}, "unknown QR code recovery level"},
+		"Invalid colour":      {256, []any{"fg", "blue"}, `invalid colour "blue"`},
+		"Invalid hex":         {256, []any{"bg", "#ggg"}, `invalid colour "#ggg"`},
+		"Negative border":     {256, []any{"border", -1}, "must not be negative"},
+		"Unknown format":      {256, []any{"format", "gif"}, `unknown QR code format "gif"`},
+		"Logo not data URI":   {256, []any{"logo", "https://example.com/logo.png"}, "must be data URI"},
+		"Logo with low level": {256, []any{"logo", logo, "level", "M"}, "requires recovery level Q or H"},
+		"SVG logo in PNG":     {256, []any{"logo", "data:image/svg+xml;base64,PHN2Zy8+"}, "requires svg format"},
+		"Broken logo":         {256, []any{"logo", "data:image/png;base64,AAAA"}, "failed to decode QR code logo"},
+	}
+	for name, tc := range errCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := qrFunc(qrTestLink, tc.size, tc.options...)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func Test_qrTemplateFunc(t *testing.T) {
+	template := `<img src="{{ qr .Link 128 }}"><img src="{{ qr "https://example.com/course" -2 "format" "svg" }}">`
+	got, err := renderHTML(template, &data{Link: qrTestLink})
+	if !assert.NoError(t, err) {
+		return
+	}
+	html := string(*got)
+	assert.Contains(t, html, `<img src="data:image/png;base64,`)
+	assert.Contains(t, html, `<img src="data:image/svg&#43;xml;base64,`)
+
+	_, err = renderHTML(`{{ qr .Link 128 "level" "X" }}`, &data{Link: qrTestLink})
+	assert.ErrorContains(t, err, "unknown QR code recovery level")
+}
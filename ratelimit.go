@@ -0,0 +1,136 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Interval between sweeps of clients whose buckets are full again
+const rateLimiterSweepInterval = time.Minute
+
+// Per-client token buckets, client is identified by IP address, see clientAddress
+type RateLimiter struct {
+	limit     rate.Limit
+	burst     int
+	mu        sync.Mutex
+	clients   map[string]*rate.Limiter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// Limiter allowing every client ratePerSec requests per second with bursts up to burst requests.
+// Zero rate returns nil limiter, which allows everything.
+func NewRateLimiter(ratePerSec float64, burst int) (*RateLimiter, error) {
+	switch {
+	case ratePerSec < 0:
+		return nil, fmt.Errorf("rate can't be negative, got %v", ratePerSec)
+	case ratePerSec == 0:
+		return nil, nil
+	case burst < 1:
+		return nil, fmt.Errorf("burst must be positive, got %d", burst)
+	}
+	return &RateLimiter{
+		limit:   rate.Limit(ratePerSec),
+		burst:   burst,
+		clients: map[string]*rate.Limiter{},
+		now:     time.Now,
+	}, nil
+}
+
+// Takes token from bucket of client, reports false if it is empty
+func (l *RateLimiter) Allow(client string) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimiterSweepInterval {
+		l.sweep(now)
+	}
+	lim, ok := l.clients[client]
+	if !ok {
+		lim = rate.NewLimiter(l.limit, l.burst)
+		l.clients[client] = lim
+	}
+	return lim.AllowN(now, 1)
+}
+
+// Forgets clients with full buckets, they are recreated the same on next request
+func (l *RateLimiter) sweep(now time.Time) {
+	for client, lim := range l.clients {
+		if lim.TokensAt(now) >= float64(l.burst) {
+			delete(l.clients, client)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Rejects calls of given CertsService methods with ResourceExhausted, mapped to 429 by REST gateway, once client runs out of tokens
+func (l *RateLimiter) UnaryInterceptor(methods ...string) grpc.UnaryServerInterceptor {
+	limited := limitedMethods(methods)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if limited[info.FullMethod] && !l.Allow(clientAddress(ctx)) {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Streaming counterpart of UnaryInterceptor, stream is rejected before it starts
+func (l *RateLimiter) StreamInterceptor(methods ...string) grpc.StreamServerInterceptor {
+	limited := limitedMethods(methods)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if limited[info.FullMethod] && !l.Allow(clientAddress(ss.Context())) {
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Limits REST handler registered by HandlePath of mux, bypassing gateway and so interceptors
+func (l *RateLimiter) Handler(mux *runtime.ServeMux, h runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if !l.Allow(requestAddress(r)) {
+			httpError(mux, w, r, status.Error(codes.ResourceExhausted, "rate limit exceeded"))
+			return
+		}
+		h(w, r, pathParams)
+	}
+}
+
+func limitedMethods(methods []string) map[string]bool {
+	limited := map[string]bool{}
+	for _, m := range methods {
+		limited["/"+api.CertsService_ServiceDesc.ServiceName+"/"+m] = true
+	}
+	return limited
+}
+
+// IP address of client, that is peerAddress without port
+func clientAddress(ctx context.Context) string {
+	addr := peerAddress(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// IP address of REST client, the same one gateway appends to x-forwarded-for
+func requestAddress(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
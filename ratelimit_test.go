@@ -0,0 +1,126 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type testAddr struct {
+	network string
+	addr    string
+}
+
+func (a testAddr) Network() string { return a.network }
+func (a testAddr) String() string  { return a.addr }
+
+func Test_NewRateLimiter(t *testing.T) {
+	t.Run("Zero rate disables limiter", func(t *testing.T) {
+		l, err := NewRateLimiter(0, 0)
+		assert.NoError(t, err)
+		assert.Nil(t, l)
+		assert.True(t, l.Allow("10.0.0.1"))
+	})
+	t.Run("Negative rate", func(t *testing.T) {
+		_, err := NewRateLimiter(-1, 1)
+		assert.ErrorContains(t, err, "rate can't be negative")
+	})
+	t.Run("Zero burst", func(t *testing.T) {
+		_, err := NewRateLimiter(1, 0)
+		assert.ErrorContains(t, err, "burst must be positive")
+	})
+}
+
+func Test_RateLimiter_Allow(t *testing.T) {
+	l, _ := NewRateLimiter(1, 2)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	assert.True(t, l.Allow("10.0.0.1"))
+	assert.True(t, l.Allow("10.0.0.1"))
+	assert.False(t, l.Allow("10.0.0.1"))
+	// Buckets are per client
+	assert.True(t, l.Allow("10.0.0.2"))
+
+	now = now.Add(time.Second)
+	assert.True(t, l.Allow("10.0.0.1"))
+	assert.False(t, l.Allow("10.0.0.1"))
+
+	t.Run("Clients with full buckets are forgotten", func(t *testing.T) {
+		now = now.Add(rateLimiterSweepInterval)
+		assert.True(t, l.Allow("10.0.0.3"))
+		assert.Len(t, l.clients, 1)
+	})
+}
+
+func Test_RateLimiter_UnaryInterceptor(t *testing.T) {
+	l, _ := NewRateLimiter(1, 1)
+	interceptor := l.UnaryInterceptor("GetCertificate")
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	call := func(method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/certs.CertsService/" + method}, handler)
+		return err
+	}
+
+	assert.NoError(t, call("GetCertificate"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("GetCertificate")))
+	// Other methods aren't limited
+	assert.NoError(t, call("ListTemplates"))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context { return s.ctx }
+
+func Test_RateLimiter_StreamInterceptor(t *testing.T) {
+	l, _ := NewRateLimiter(1, 1)
+	interceptor := l.StreamInterceptor("DownloadCertificate")
+	handler := func(srv any, stream grpc.ServerStream) error { return nil }
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	call := func(method string) error {
+		return interceptor(nil, testServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/certs.CertsService/" + method}, handler)
+	}
+
+	assert.NoError(t, call("DownloadCertificate"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("DownloadCertificate")))
+}
+
+func Test_RateLimiter_Handler(t *testing.T) {
+	l, _ := NewRateLimiter(1, 1)
+	mux := runtime.NewServeMux()
+	h := l.Handler(mux, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {})
+	call := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/certificate/id/download", nil)
+		req.RemoteAddr = remoteAddr
+		resp := httptest.NewRecorder()
+		h(resp, req, nil)
+		return resp.Code
+	}
+
+	assert.Equal(t, http.StatusOK, call("10.0.0.1:5000"))
+	assert.Equal(t, http.StatusTooManyRequests, call("10.0.0.1:5001"))
+	assert.Equal(t, http.StatusOK, call("10.0.0.2:5000"))
+}
+
+func Test_clientAddress(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	assert.Equal(t, "10.0.0.1", clientAddress(ctx))
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: testAddr{gatewayNetwork, "bufconn"}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(forwardedForMetadataKey, "10.0.0.2"))
+	assert.Equal(t, "10.0.0.2", clientAddress(ctx))
+}
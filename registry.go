@@ -4,33 +4,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"golang.org/x/exp/slices"
 )
 
 type Registry interface {
-	AddTemplate(string, string) error
-	ListTemplates() ([]string, error)
-	DeleteTemplate(int) error
-	GetTemplatePK(string) (int, error)
-	GetTemplateContent(int) (*string, error)
-	CertificatesByTemplatePK(int) ([]string, error)
-	UpdateTemplate(int, map[string]string) error
-	AddCertificate(string, string, string, string, string) (*Certificate, error)
-	DeleteCertificate(string) error
-	GetCertificate(string) (*Certificate, error)
-	UpdateCertificate(string, map[string]string) error
+	AddTemplate(context.Context, string, string) error
+	ListTemplates(context.Context, bool) ([]string, error)
+	DeleteTemplate(context.Context, int) error
+	RestoreTemplate(context.Context, string) error
+	GetTemplatePK(context.Context, string) (int, error)
+	GetTemplateContent(context.Context, int) (*string, error)
+	GetTemplate(context.Context, int) (*Template, error)
+	CertificatesByTemplatePK(context.Context, int) ([]string, error)
+	UpdateTemplate(context.Context, int, TemplateUpdate) error
+	AddCertificate(context.Context, string, string, string, string, string, *time.Time, *time.Time) (*Certificate, error)
+	DeleteCertificate(context.Context, string) error
+	RestoreCertificate(context.Context, string) error
+	GetCertificate(context.Context, string) (*Certificate, error)
+	ListExpiringCertificates(context.Context, time.Duration) ([]*Certificate, error)
+	UpdateCertificate(context.Context, string, CertificateUpdate) (time.Time, error)
+	RevokeCertificate(context.Context, string, string) error
+	UnrevokeCertificate(context.Context, string) error
+	ListAuditEvents(context.Context, AuditFilter) ([]AuditEvent, error)
+	PurgeDeleted(context.Context, time.Time) (int, error)
 }
 
 type DirectRegistry struct {
 	p pool
+	// Generator of certificate ids, database generates legacy ids if nil
+	ids IdGenerator
 }
 
+// Attempts to generate unused certificate id before giving up
+const maxIdAttempts = 5
+
 // Interface for ease of mocking, exposing only used methods of pgxpool.Pool
 type pool interface {
 	Ping(context.Context) error
@@ -41,17 +54,89 @@ type pool interface {
 	Exec(context.Context, string, ...any) (pgconn.CommandTag, error)
 }
 
+// Common part of pool and pgx.Tx, so single row selects can run in or out of transaction
+type querier interface {
+	QueryRow(context.Context, string, ...any) pgx.Row
+}
+
 type Certificate struct {
-	Id         string
-	TemplatePk int
-	Timestamp  time.Time
-	Student    string
-	IssueDate  string
-	Course     string
-	Mentors    string
+	Id         string    `json:"id"`
+	TemplatePk int       `json:"template"`
+	Timestamp  time.Time `json:"timestamp"`
+	Student    string    `json:"student"`
+	IssueDate  string    `json:"issue_date"`
+	Course     string    `json:"course"`
+	Mentors    string    `json:"mentors"`
+	// Set only for revoked certificates
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevocationReason string     `json:"revocation_reason,omitempty"`
+	// Validity window, unbounded on the side which is nil
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// Whether certificate validity window has ended by time t
+func (c *Certificate) ExpiredAt(t time.Time) bool {
+	return c.ValidUntil != nil && !t.Before(*c.ValidUntil)
+}
+
+// Whether certificate validity window has not yet started by time t
+func (c *Certificate) NotYetValidAt(t time.Time) bool {
+	return c.ValidFrom != nil && t.Before(*c.ValidFrom)
+}
+
+// Template details, except content itself
+type Template struct {
+	Pk        int       `json:"pk"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Changes when template is renamed or its content is updated
+	UpdatedAt time.Time `json:"updated_at"`
+	// Number of live certificates using template
+	CertificateCount int `json:"certificate_count"`
+	// Hex encoded SHA-256 of content
+	ContentHash string `json:"content_hash"`
+}
+
+// Value of a field in partial update, field is changed only if Set
+type Field[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Field to be changed to v
+func Set[T any](v T) Field[T] {
+	return Field[T]{Value: v, Set: true}
+}
+
+// Changes of template, fields which are not Set are left as is
+type TemplateUpdate struct {
+	Name    Field[string]
+	Content Field[string]
+}
+
+// Changes of certificate, fields which are not Set are left as is.
+// Template is given by name, nil validity bound removes it.
+type CertificateUpdate struct {
+	Template   Field[string]
+	Student    Field[string]
+	IssueDate  Field[string]
+	Course     Field[string]
+	Mentors    Field[string]
+	ValidFrom  Field[*time.Time]
+	ValidUntil Field[*time.Time]
 }
 
-func NewDirectRegistry(connString string) (*DirectRegistry, error) {
+var ErrNotFound = errors.New("not found")
+
+// Template state as it recorded in audit log
+type templateSnapshot struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Applies pending migrations. Nil ids leaves id generation to database
+func NewDirectRegistry(connString string, ids IdGenerator) (*DirectRegistry, error) {
 	p, err := initDB(connString)
 	if err != nil {
 		return nil, err
@@ -60,8 +145,30 @@ func NewDirectRegistry(connString string) (*DirectRegistry, error) {
 	if err = p.Ping(context.Background()); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
+	// Schema is brought up to date before registry is used
+	m, err := newMigrator(p, migrationFiles)
+	if err == nil {
+		_, err = m.Up(context.Background())
+	}
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &DirectRegistry{p: p, ids: ids}, nil
+}
+
+// Checks that connection to database can be acquired
+func (dr *DirectRegistry) CheckHealth(ctx context.Context) error {
+	if err := dr.p.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
 
-	return &DirectRegistry{p}, nil
+// Closes all connections to database, waiting for acquired ones to be released
+func (dr *DirectRegistry) Close() {
+	dr.p.Close()
 }
 
 func initDB(connString string) (p pool, err error) {
@@ -71,8 +178,8 @@ func initDB(connString string) (p pool, err error) {
 	return p, nil
 }
 
-func (dr *DirectRegistry) AddTemplate(name string, content string) (err error) {
-	tx, err := dr.p.Begin(context.Background())
+func (dr *DirectRegistry) AddTemplate(ctx context.Context, name string, content string) (err error) {
+	tx, err := dr.p.Begin(ctx)
 	if err != nil {
 		return
 	}
@@ -80,29 +187,37 @@ func (dr *DirectRegistry) AddTemplate(name string, content string) (err error) {
 	defer func() {
 		switch err {
 		case nil:
-			err = tx.Commit(context.Background())
+			err = tx.Commit(ctx)
 		default:
-			_ = tx.Rollback(context.Background())
+			_ = tx.Rollback(ctx)
 		}
 	}()
 
 	var id int
-	row := tx.QueryRow(context.Background(),
+	row := tx.QueryRow(ctx,
 		"INSERT INTO template_content (content) VALUES ($1) RETURNING id", content)
 	err = row.Scan(&id)
 	if err != nil {
 		return fmt.Errorf("unable to INSERT INTO template_content: %w", err)
 	}
-	_, err = tx.Exec(context.Background(),
-		"INSERT INTO template (name, content) VALUES ($1, $2)", name, id)
+	var pk int
+	row = tx.QueryRow(ctx,
+		"INSERT INTO template (name, content) VALUES ($1, $2) RETURNING id", name, id)
+	err = row.Scan(&pk)
 	if err != nil {
 		return fmt.Errorf("unable to INSERT INTO template: %w", err)
 	}
-	return nil
+	return audit(ctx, tx, AuditCreate, AuditTemplate, strconv.Itoa(pk),
+		nil, &templateSnapshot{name, content})
 }
 
-func (dr *DirectRegistry) ListTemplates() (names []string, err error) {
-	rows, err := dr.p.Query(context.Background(), "SELECT name FROM template")
+// Soft-deleted templates are listed only when withDeleted is set
+func (dr *DirectRegistry) ListTemplates(ctx context.Context, withDeleted bool) (names []string, err error) {
+	q := "SELECT name FROM template WHERE deleted_at IS NULL"
+	if withDeleted {
+		q = "SELECT name FROM template"
+	}
+	rows, err := dr.p.Query(ctx, q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to SELECT name FROM template: %w", err)
 	}
@@ -113,8 +228,10 @@ func (dr *DirectRegistry) ListTemplates() (names []string, err error) {
 	return names, nil
 }
 
-func (dr *DirectRegistry) DeleteTemplate(pk int) (err error) {
-	tx, err := dr.p.Begin(context.Background())
+// Marks template as deleted. It is refused while template has live certificates,
+// and hard-deleted by PurgeDeleted after retention period.
+func (dr *DirectRegistry) DeleteTemplate(ctx context.Context, pk int) (err error) {
+	tx, err := dr.p.Begin(ctx)
 	if err != nil {
 		return
 	}
@@ -122,42 +239,93 @@ func (dr *DirectRegistry) DeleteTemplate(pk int) (err error) {
 	defer func() {
 		switch err {
 		case nil:
-			err = tx.Commit(context.Background())
+			err = tx.Commit(ctx)
 		default:
-			_ = tx.Rollback(context.Background())
+			_ = tx.Rollback(ctx)
 		}
 	}()
 
-	var contentId int
-	row := tx.QueryRow(context.Background(),
-		"SELECT content FROM template WHERE id=$1", pk)
-	err = row.Scan(&contentId)
+	before, err := selectTemplate(ctx, tx, pk)
 	if err != nil {
-		return fmt.Errorf("unable to SELECT content FROM template: %w", err)
+		return err
 	}
 
-	commandTag, err := tx.Exec(context.Background(),
-		"DELETE FROM template WHERE id=$1", pk)
+	var live int
+	row := tx.QueryRow(ctx,
+		"SELECT count(*) FROM certificate WHERE template=$1 AND deleted_at IS NULL", pk)
+	err = row.Scan(&live)
 	if err != nil {
-		return fmt.Errorf("unable to DELETE FROM template: %w", err)
-	} else if commandTag.RowsAffected() != 1 {
-		return errors.New("no row found to DELETE FROM template")
+		return fmt.Errorf("unable to SELECT count(*) FROM certificate: %w", err)
+	}
+	if live != 0 {
+		return fmt.Errorf("template with id %d is used by %d certificates", pk, live)
 	}
 
-	commandTag, err = tx.Exec(context.Background(),
-		"DELETE FROM template_content WHERE id=$1", contentId)
+	commandTag, err := tx.Exec(ctx,
+		"UPDATE template SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", pk)
 	if err != nil {
-		return fmt.Errorf("unable to DELETE FROM template_content: %w", err)
+		return fmt.Errorf("unable to UPDATE template: %w", err)
 	} else if commandTag.RowsAffected() != 1 {
-		return errors.New("no row found to DELETE FROM template_content")
+		return errors.New("no row found to UPDATE template")
 	}
 
-	return nil
+	return audit(ctx, tx, AuditDelete, AuditTemplate, strconv.Itoa(pk), before, nil)
 }
 
-func (dr *DirectRegistry) GetTemplatePK(name string) (pk int, err error) {
-	row := dr.p.QueryRow(context.Background(),
-		"SELECT id FROM template WHERE name=$1", name)
+// Restores the most recently deleted template with given name.
+// Fails if live template with the same name exists.
+func (dr *DirectRegistry) RestoreTemplate(ctx context.Context, name string) (err error) {
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var pk int
+	row := tx.QueryRow(ctx,
+		`UPDATE template SET deleted_at=NULL
+		 WHERE id=(SELECT id FROM template WHERE name=$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT 1)
+		 RETURNING id`, name)
+	err = row.Scan(&pk)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("unable to restore template %s: %w", name, err)
+	}
+
+	after, err := selectTemplate(ctx, tx, pk)
+	if err != nil {
+		return err
+	}
+	return audit(ctx, tx, AuditRestore, AuditTemplate, strconv.Itoa(pk), nil, after)
+}
+
+// Returns state of live template for auditing
+func selectTemplate(ctx context.Context, q querier, pk int) (*templateSnapshot, error) {
+	t := &templateSnapshot{}
+	row := q.QueryRow(ctx,
+		`SELECT template.name, template_content.content
+		 FROM template JOIN template_content ON template_content.id = template.content
+		 WHERE template.id=$1 AND template.deleted_at IS NULL`, pk)
+	err := row.Scan(&t.Name, &t.Content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to SELECT content FROM template: %w", err)
+	}
+	return t, nil
+}
+
+func (dr *DirectRegistry) GetTemplatePK(ctx context.Context, name string) (pk int, err error) {
+	row := dr.p.QueryRow(ctx,
+		"SELECT id FROM template WHERE name=$1 AND deleted_at IS NULL", name)
 	err = row.Scan(&pk)
 	if err != nil {
 		return 0, fmt.Errorf("unable to SELECT id FROM template: %w", err)
@@ -165,26 +333,48 @@ func (dr *DirectRegistry) GetTemplatePK(name string) (pk int, err error) {
 	return
 }
 
-func (dr *DirectRegistry) GetTemplateContent(pk int) (content *string, err error) {
+func (dr *DirectRegistry) GetTemplateContent(ctx context.Context, pk int) (content *string, err error) {
 	var contentId int
-	row := dr.p.QueryRow(context.Background(),
-		"SELECT content FROM template WHERE id=$1", pk)
+	row := dr.p.QueryRow(ctx,
+		"SELECT content FROM template WHERE id=$1 AND deleted_at IS NULL", pk)
 	err = row.Scan(&contentId)
 	if err != nil {
 		return nil, fmt.Errorf("unable to SELECT content FROM template: %w", err)
 	}
-	row = dr.p.QueryRow(context.Background(),
+	row = dr.p.QueryRow(ctx,
 		"SELECT content FROM template_content WHERE id=$1", contentId)
-	var ctx string
-	err = row.Scan(&ctx)
+	var c string
+	err = row.Scan(&c)
 	if err != nil {
 		return nil, fmt.Errorf("unable to SELECT content FROM template_content: %w", err)
 	}
-	return &ctx, nil
+	return &c, nil
 }
 
-func (dr *DirectRegistry) UpdateTemplate(pk int, m map[string]string) (err error) {
-	tx, err := dr.p.Begin(context.Background())
+func (dr *DirectRegistry) GetTemplate(ctx context.Context, pk int) (*Template, error) {
+	t := Template{Pk: pk}
+	row := dr.p.QueryRow(ctx,
+		`SELECT template.name, template.created_at, template.updated_at,
+		 (SELECT count(*) FROM certificate WHERE certificate.template=template.id AND certificate.deleted_at IS NULL),
+		 encode(sha256(convert_to(template_content.content, 'UTF8')), 'hex')
+		 FROM template JOIN template_content ON template_content.id=template.content
+		 WHERE template.id=$1 AND template.deleted_at IS NULL`, pk)
+	err := row.Scan(&t.Name, &t.CreatedAt, &t.UpdatedAt, &t.CertificateCount, &t.ContentHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to SELECT template with id %d: %w", pk, err)
+	}
+	return &t, nil
+}
+
+func (dr *DirectRegistry) UpdateTemplate(ctx context.Context, pk int, u TemplateUpdate) (err error) {
+	if !u.Name.Set && !u.Content.Set {
+		return errors.New("no fields to update")
+	}
+
+	tx, err := dr.p.Begin(ctx)
 	if err != nil {
 		return
 	}
@@ -192,82 +382,221 @@ func (dr *DirectRegistry) UpdateTemplate(pk int, m map[string]string) (err error
 	defer func() {
 		switch err {
 		case nil:
-			err = tx.Commit(context.Background())
+			err = tx.Commit(ctx)
 		default:
-			_ = tx.Rollback(context.Background())
+			_ = tx.Rollback(ctx)
 		}
 	}()
 
-	for k, v := range m {
-		switch k {
-		case "name", "Name":
-			commandTag, err := tx.Exec(context.Background(),
-				"UPDATE template SET name=$1 WHERE id=$2",
-				v, pk)
-			if err != nil {
-				return fmt.Errorf("unable to UPDATE template: %w", err)
-			} else if commandTag.RowsAffected() != 1 {
-				return errors.New("no row found to UPDATE template")
-			}
-		case "content", "Content":
-			commandTag, err := tx.Exec(context.Background(),
-				"UPDATE template_content SET content=$1 WHERE id=(SELECT content FROM template WHERE id=$2)",
-				v, pk)
-			if err != nil {
-				return fmt.Errorf("unable to UPDATE template_content: %w", err)
-			} else if commandTag.RowsAffected() != 1 {
-				return errors.New("no row found to UPDATE template_content")
-			}
-		default:
-			return fmt.Errorf("illegal key in a map")
+	before, err := selectTemplate(ctx, tx, pk)
+	if err != nil {
+		return err
+	}
+
+	if u.Name.Set {
+		commandTag, err := tx.Exec(ctx,
+			"UPDATE template SET name=$1 WHERE id=$2",
+			u.Name.Value, pk)
+		if err != nil {
+			return fmt.Errorf("unable to UPDATE template: %w", err)
+		} else if commandTag.RowsAffected() != 1 {
+			return errors.New("no row found to UPDATE template")
 		}
 	}
-	return nil
+	if u.Content.Set {
+		commandTag, err := tx.Exec(ctx,
+			"UPDATE template_content SET content=$1 WHERE id=(SELECT content FROM template WHERE id=$2)",
+			u.Content.Value, pk)
+		if err != nil {
+			return fmt.Errorf("unable to UPDATE template_content: %w", err)
+		} else if commandTag.RowsAffected() != 1 {
+			return errors.New("no row found to UPDATE template_content")
+		}
+	}
+
+	after, err := selectTemplate(ctx, tx, pk)
+	if err != nil {
+		return err
+	}
+	return audit(ctx, tx, AuditUpdate, AuditTemplate, strconv.Itoa(pk), before, after)
 }
 
-func (dr *DirectRegistry) AddCertificate(templateName, student, issueDate, course, mentors string) (*Certificate, error) {
-	cert := &Certificate{}
-	row := dr.p.QueryRow(context.Background(),
-		"SELECT id FROM template WHERE name=$1", templateName)
-	err := row.Scan(&cert.TemplatePk)
+// Nil validFrom or validUntil leaves validity window open on that side
+func (dr *DirectRegistry) AddCertificate(ctx context.Context, templateName, student, issueDate, course, mentors string,
+	validFrom, validUntil *time.Time) (cert *Certificate, err error) {
+	tx, err := dr.p.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to scan Id for template %s: %w", templateName, err)
+		return
 	}
 
-	row = dr.p.QueryRow(context.Background(),
-		`INSERT INTO certificate (template, student, issue_date, course, mentors)
-		 VALUES ($1, $2, $3, $4, $5)
-		 RETURNING id, timestamp`,
-		cert.TemplatePk, student, issueDate, course, mentors)
-	err = row.Scan(&cert.Id, &cert.Timestamp)
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+		if err != nil {
+			cert = nil
+		}
+	}()
+
+	cert = &Certificate{}
+	row := tx.QueryRow(ctx,
+		"SELECT id FROM template WHERE name=$1 AND deleted_at IS NULL", templateName)
+	err = row.Scan(&cert.TemplatePk)
 	if err != nil {
-		return nil, fmt.Errorf("unable to scan Id and/or timestamp fields after INSERT INTO certificate: %w", err)
+		return nil, fmt.Errorf("unable to scan Id for template %s: %w", templateName, err)
+	}
+
+	// Insert is skipped on id collision, so transaction stays usable for retry with another id
+	for attempt := 1; ; attempt++ {
+		var id *string
+		if dr.ids != nil {
+			newId, err := dr.ids.NewId()
+			if err != nil {
+				return nil, err
+			}
+			id = &newId
+		}
+		row = tx.QueryRow(ctx,
+			`INSERT INTO certificate (id, template, student, issue_date, course, mentors, valid_from, valid_until)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 ON CONFLICT (id) DO NOTHING
+			 RETURNING id, timestamp`,
+			id, cert.TemplatePk, student, issueDate, course, mentors, validFrom, validUntil)
+		err = row.Scan(&cert.Id, &cert.Timestamp)
+		if errors.Is(err, pgx.ErrNoRows) && dr.ids != nil && attempt < maxIdAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan Id and/or timestamp fields after INSERT INTO certificate: %w", err)
+		}
+		break
 	}
 
 	cert.Student = student
 	cert.IssueDate = issueDate
 	cert.Course = course
 	cert.Mentors = mentors
+	cert.ValidFrom = validFrom
+	cert.ValidUntil = validUntil
+
+	err = audit(ctx, tx, AuditCreate, AuditCertificate, cert.Id, nil, cert)
+	if err != nil {
+		return nil, err
+	}
 	return cert, nil
 }
 
-func (dr *DirectRegistry) DeleteCertificate(id string) error {
-	ct, err := dr.p.Exec(context.Background(),
-		"DELETE FROM certificate WHERE id=$1", id)
+// Marks certificate as deleted, it is hard-deleted by PurgeDeleted after retention period
+func (dr *DirectRegistry) DeleteCertificate(ctx context.Context, id string) (err error) {
+	if id, err = dr.checkId(id); err != nil {
+		return err
+	}
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	ct, err := tx.Exec(ctx,
+		"UPDATE certificate SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return fmt.Errorf("unable to UPDATE certificate: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("no rows affected when attempt to delete certificate with id: %v", id)
+	}
+
+	return audit(ctx, tx, AuditDelete, AuditCertificate, id, before, nil)
+}
+
+// Restores deleted certificate, provided its template is not deleted
+func (dr *DirectRegistry) RestoreCertificate(ctx context.Context, id string) (err error) {
+	if id, err = dr.checkId(id); err != nil {
+		return err
+	}
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	ct, err := tx.Exec(ctx,
+		`UPDATE certificate SET deleted_at=NULL
+		 WHERE id=$1 AND deleted_at IS NOT NULL
+		 AND template IN (SELECT id FROM template WHERE deleted_at IS NULL)`, id)
 	if err != nil {
-		return fmt.Errorf("unable to DELETE FROM certificate: %w", err)
+		return fmt.Errorf("unable to UPDATE certificate: %w", err)
 	}
 	if ct.RowsAffected() == 0 {
-		return fmt.Errorf("no rows affected when attempt to DELETE FROM certificate with id: %v", id)
+		// Either certificate is not deleted, or its template is
+		return fmt.Errorf("unable to restore certificate with Id %s: %w", id, ErrNotFound)
+	}
+
+	after, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return audit(ctx, tx, AuditRestore, AuditCertificate, id, nil, after)
+}
+
+func (dr *DirectRegistry) GetCertificate(ctx context.Context, id string) (*Certificate, error) {
+	id, err := dr.checkId(id)
+	if err != nil {
+		return nil, err
+	}
+	return selectCertificate(ctx, dr.p, id)
+}
+
+// Certificate columns read by scanCertificate, except id
+const certificateFields = `template, timestamp, student, issue_date, course, mentors,
+	revoked_at, revocation_reason, valid_from, valid_until`
+
+// Scans row of certificateFields into cert, preceded by extra destinations if any
+func scanCertificate(row pgx.Row, cert *Certificate, dest ...any) error {
+	var reason *string
+	dest = append(dest, &cert.TemplatePk, &cert.Timestamp, &cert.Student, &cert.IssueDate, &cert.Course, &cert.Mentors,
+		&cert.RevokedAt, &reason, &cert.ValidFrom, &cert.ValidUntil)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+	if reason != nil {
+		cert.RevocationReason = *reason
 	}
 	return nil
 }
 
-func (dr *DirectRegistry) GetCertificate(id string) (*Certificate, error) {
+func selectCertificate(ctx context.Context, q querier, id string) (*Certificate, error) {
 	cert := &Certificate{}
-	row := dr.p.QueryRow(context.Background(),
-		"SELECT template, timestamp, student, issue_date, course, mentors FROM certificate WHERE id=$1", id)
-	err := row.Scan(&cert.TemplatePk, &cert.Timestamp, &cert.Student, &cert.IssueDate, &cert.Course, &cert.Mentors)
+	row := q.QueryRow(ctx,
+		"SELECT "+certificateFields+" FROM certificate WHERE id=$1 AND deleted_at IS NULL", id)
+	err := scanCertificate(row, cert)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to get certificate with Id %s: %w", id, err)
 	}
@@ -276,33 +605,146 @@ func (dr *DirectRegistry) GetCertificate(id string) (*Certificate, error) {
 	return cert, nil
 }
 
-func (dr *DirectRegistry) UpdateCertificate(id string, m map[string]string) error {
-	fields := []string{
-		"template", "Template",
-		"student", "Student",
-		"issue_date", "Issue_date",
-		"course", "Course",
-		"mentors", "Mentors"}
-	s := []string{}
-	for k, v := range m {
-		if !slices.Contains(fields, k) {
-			return fmt.Errorf("illegal key in a map")
+// Rejects id which could not be issued, legacy ids are always accepted.
+// Returns id in form it was issued in, as ids may be typed differently, e.g. in lower case.
+func (dr *DirectRegistry) checkId(id string) (string, error) {
+	if LegacyIdFormat.Valid(id) {
+		return id, nil
+	}
+	if dr.ids != nil && dr.ids.Valid(id) {
+		if n, ok := dr.ids.(idNormalizer); ok {
+			return n.Normalize(id), nil
 		}
-		s = append(s, fmt.Sprintf("%s='%s'", k, v))
+		return id, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidId, id)
+}
+
+// Returns live, not revoked certificates whose validity ends within given duration from now, soonest first.
+// Window is computed by database clock, which validity is compared against.
+func (dr *DirectRegistry) ListExpiringCertificates(ctx context.Context, within time.Duration) (certs []*Certificate, err error) {
+	rows, err := dr.p.Query(ctx,
+		"SELECT id, "+certificateFields+` FROM certificate
+		 WHERE deleted_at IS NULL AND revoked_at IS NULL AND valid_until >= now() AND valid_until < now() + make_interval(secs => $1)
+		 ORDER BY valid_until`, within.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("unable to SELECT FROM certificate: %w", err)
 	}
-	q := fmt.Sprintf("UPDATE certificate SET %s WHERE id=$1", strings.Join(s, ","))
-	ct, err := dr.p.Exec(context.Background(), q, id)
+	certs, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Certificate, error) {
+		cert := &Certificate{}
+		err := scanCertificate(row, cert, &cert.Id)
+		return cert, err
+	})
 	if err != nil {
-		return fmt.Errorf("unable to UPDATE certificate: %w", err)
+		return nil, fmt.Errorf("unable to convert request into certificates list: %w", err)
 	}
-	if ct.RowsAffected() == 0 {
-		return fmt.Errorf("no rows affected when attempt to UPDATE certificate with id: %v", id)
+	return certs, nil
+}
+
+// Builds SET clause of UPDATE statement with parameterised values.
+// Column names must come from a fixed list and never from a caller.
+type partialUpdate struct {
+	columns []string
+	args    []any
+}
+
+// Placeholders of set values are numbered after leading args, e.g. row id
+func newPartialUpdate(leading ...any) *partialUpdate {
+	return &partialUpdate{args: leading}
+}
+
+func (q *partialUpdate) set(column string, value any) {
+	q.columns = append(q.columns, column)
+	q.args = append(q.args, value)
+}
+
+func (q *partialUpdate) clause() string {
+	s := make([]string, len(q.columns))
+	first := len(q.args) - len(q.columns) + 1
+	for i, c := range q.columns {
+		s[i] = fmt.Sprintf("%s=$%d", c, first+i)
 	}
-	return nil
+	return strings.Join(s, ", ")
 }
 
-func (dr *DirectRegistry) CertificatesByTemplatePK(pk int) (ids []string, err error) {
-	rows, err := dr.p.Query(context.Background(), "SELECT id FROM certificate WHERE template=$1", pk)
+// Applies changes to certificate and returns its new timestamp
+func (dr *DirectRegistry) UpdateCertificate(ctx context.Context, id string, u CertificateUpdate) (timestamp time.Time, err error) {
+	if id, err = dr.checkId(id); err != nil {
+		return
+	}
+	q := newPartialUpdate(id)
+	if u.Student.Set {
+		q.set("student", u.Student.Value)
+	}
+	if u.IssueDate.Set {
+		q.set("issue_date", u.IssueDate.Value)
+	}
+	if u.Course.Set {
+		q.set("course", u.Course.Value)
+	}
+	if u.Mentors.Set {
+		q.set("mentors", u.Mentors.Value)
+	}
+	if u.ValidFrom.Set {
+		q.set("valid_from", u.ValidFrom.Value)
+	}
+	if u.ValidUntil.Set {
+		q.set("valid_until", u.ValidUntil.Value)
+	}
+	if len(q.columns) == 0 && !u.Template.Set {
+		return timestamp, errors.New("no fields to update")
+	}
+
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return
+	}
+
+	if u.Template.Set {
+		var pk int
+		err = tx.QueryRow(ctx, "SELECT id FROM template WHERE name=$1 AND deleted_at IS NULL", u.Template.Value).Scan(&pk)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return timestamp, fmt.Errorf("%w: template with name %q", ErrNotFound, u.Template.Value)
+		}
+		if err != nil {
+			return timestamp, fmt.Errorf("unable to SELECT id FROM template: %w", err)
+		}
+		q.set("template", pk)
+	}
+
+	err = tx.QueryRow(ctx,
+		"UPDATE certificate SET "+q.clause()+" WHERE id=$1 AND deleted_at IS NULL RETURNING timestamp",
+		q.args...).Scan(&timestamp)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return timestamp, fmt.Errorf("%w: certificate with id %v", ErrNotFound, id)
+	}
+	if err != nil {
+		return timestamp, fmt.Errorf("unable to UPDATE certificate: %w", err)
+	}
+
+	after, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return
+	}
+	return timestamp, audit(ctx, tx, AuditUpdate, AuditCertificate, id, before, after)
+}
+
+func (dr *DirectRegistry) CertificatesByTemplatePK(ctx context.Context, pk int) (ids []string, err error) {
+	rows, err := dr.p.Query(ctx, "SELECT id FROM certificate WHERE template=$1 AND deleted_at IS NULL", pk)
 	if err != nil {
 		return nil, fmt.Errorf("unable to SELECT id FROM certificate: %w", err)
 	}
@@ -312,3 +754,147 @@ func (dr *DirectRegistry) CertificatesByTemplatePK(pk int) (ids []string, err er
 	}
 	return ids, nil
 }
+
+func (dr *DirectRegistry) RevokeCertificate(ctx context.Context, id string, reason string) (err error) {
+	if id, err = dr.checkId(id); err != nil {
+		return err
+	}
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before.RevokedAt != nil {
+		return fmt.Errorf("certificate with Id %s is already revoked", id)
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE certificate SET revoked_at=now(), revocation_reason=$2 WHERE id=$1", id, reason)
+	if err != nil {
+		return fmt.Errorf("unable to UPDATE certificate: %w", err)
+	}
+
+	after, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return audit(ctx, tx, AuditRevoke, AuditCertificate, id, before, after)
+}
+
+func (dr *DirectRegistry) UnrevokeCertificate(ctx context.Context, id string) (err error) {
+	if id, err = dr.checkId(id); err != nil {
+		return err
+	}
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before.RevokedAt == nil {
+		return fmt.Errorf("certificate with Id %s is not revoked", id)
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE certificate SET revoked_at=NULL, revocation_reason=NULL WHERE id=$1", id)
+	if err != nil {
+		return fmt.Errorf("unable to UPDATE certificate: %w", err)
+	}
+
+	after, err := selectCertificate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return audit(ctx, tx, AuditUnrevoke, AuditCertificate, id, before, after)
+}
+
+// Hard-deletes certificates and templates soft-deleted before given time,
+// recording their last state in audit log. Templates still referenced by any certificate are kept.
+// Returns number of purged rows.
+func (dr *DirectRegistry) PurgeDeleted(ctx context.Context, before time.Time) (n int, err error) {
+	tx, err := dr.p.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+		if err != nil {
+			n = 0
+		}
+	}()
+
+	actor := ActorFromContext(ctx)
+	ct, err := tx.Exec(ctx,
+		`WITH purged AS (
+			DELETE FROM certificate WHERE deleted_at < $1 RETURNING *
+		)
+		INSERT INTO audit_event (actor, action, entity, entity_id, before)
+		SELECT $2::text, $3::text, $4::text, purged.id, to_jsonb(purged) FROM purged`,
+		before, actor, AuditPurge, AuditCertificate)
+	if err != nil {
+		return 0, fmt.Errorf("unable to DELETE FROM certificate: %w", err)
+	}
+	n = int(ct.RowsAffected())
+
+	rows, err := tx.Query(ctx,
+		`WITH purged AS (
+			DELETE FROM template WHERE deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM certificate WHERE certificate.template = template.id)
+			RETURNING id, name, content
+		), audited AS (
+			INSERT INTO audit_event (actor, action, entity, entity_id, before)
+			SELECT $2::text, $3::text, $4::text, purged.id::text,
+			jsonb_build_object('name', purged.name, 'content', template_content.content)
+			FROM purged JOIN template_content ON template_content.id = purged.content
+		)
+		SELECT content FROM purged`,
+		before, actor, AuditPurge, AuditTemplate)
+	if err != nil {
+		return 0, fmt.Errorf("unable to DELETE FROM template: %w", err)
+	}
+	contentIds, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert request into content ids list: %w", err)
+	}
+	if len(contentIds) == 0 {
+		return n, nil
+	}
+
+	_, err = tx.Exec(ctx,
+		"DELETE FROM template_content WHERE id = ANY($1)", contentIds)
+	if err != nil {
+		return 0, fmt.Errorf("unable to DELETE FROM template_content: %w", err)
+	}
+	return n + len(contentIds), nil
+}
@@ -1,10 +1,14 @@
 package golangunitedschoolcerts
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v2"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,7 +16,34 @@ import (
 // Check that struct implements interface
 var _ Registry = &DirectRegistry{}
 
+func expectTemplateSelect(mock pgxmock.PgxPoolIface, pk int, name string, content string) {
+	rows := pgxmock.NewRows([]string{"name", "content"}).AddRow(name, content)
+	mock.ExpectQuery("SELECT template.name, template_content.content").WithArgs(pk).
+		WillReturnRows(rows)
+}
+
+var certificateColumns = []string{"template", "timestamp", "student", "issue_date", "course", "mentors",
+	"revoked_at", "revocation_reason", "valid_from", "valid_until"}
+
+func expectCertificateSelect(mock pgxmock.PgxPoolIface, cert *Certificate) {
+	var reason *string
+	if cert.RevokedAt != nil {
+		reason = &cert.RevocationReason
+	}
+	rows := pgxmock.NewRows(certificateColumns).
+		AddRow(cert.TemplatePk, cert.Timestamp, cert.Student, cert.IssueDate, cert.Course, cert.Mentors,
+			cert.RevokedAt, reason, cert.ValidFrom, cert.ValidUntil)
+	mock.ExpectQuery("SELECT template, timestamp").WithArgs(cert.Id).WillReturnRows(rows)
+}
+
+func expectAudit(mock pgxmock.PgxPoolIface, action string, entity string, entityId string) {
+	mock.ExpectExec("INSERT INTO audit_event").
+		WithArgs(AnonymousActor, action, entity, entityId, pgxmock.AnyArg(), pgxmock.AnyArg(), "").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+}
+
 func Test_DirectRegistry_AddTemplate(t *testing.T) {
+	ctx := context.Background()
 	t.Run("Check inserting into template and template_content tables (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -21,18 +52,20 @@ func Test_DirectRegistry_AddTemplate(t *testing.T) {
 		defer mock.Close()
 
 		id := 1
+		pk := 2
 		name := "Test name"
 		content := "Test content"
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := pgxmock.NewRows([]string{"id"}).AddRow(id)
 		mock.ExpectBegin()
 		mock.ExpectQuery("INSERT INTO template_content").WithArgs(content).
 			WillReturnRows(rows)
-		mock.ExpectExec("INSERT INTO template").WithArgs(name, id).
-			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectQuery("INSERT INTO template").WithArgs(name, id).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(pk))
+		expectAudit(mock, AuditCreate, AuditTemplate, "2")
 		mock.ExpectCommit()
 
-		err = dr.AddTemplate(name, content)
+		err = dr.AddTemplate(ctx, name, content)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
@@ -48,21 +81,49 @@ func Test_DirectRegistry_AddTemplate(t *testing.T) {
 		id := 1
 		name := "Test name"
 		content := "Test content"
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := pgxmock.NewRows([]string{"id"}).AddRow(id)
 		mock.ExpectBegin()
 		mock.ExpectQuery("INSERT INTO template_content").WithArgs(content).
 			WillReturnRows(rows)
-		mock.ExpectExec("INSERT INTO template").WithArgs(name, id).
+		mock.ExpectQuery("INSERT INTO template").WithArgs(name, id).
 			WillReturnError(fmt.Errorf("id error"))
 		mock.ExpectRollback()
 
-		err = dr.AddTemplate(name, content)
+		err = dr.AddTemplate(ctx, name, content)
 		assert.ErrorContains(t, err, "id error")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 
+	t.Run("Check inserting into template and template_content tables (audit error)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		id := 1
+		pk := 2
+		name := "Test name"
+		content := "Test content"
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO template_content").WithArgs(content).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(id))
+		mock.ExpectQuery("INSERT INTO template").WithArgs(name, id).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(pk))
+		mock.ExpectExec("INSERT INTO audit_event").
+			WithArgs("admin", AuditCreate, AuditTemplate, "2", []byte(nil), pgxmock.AnyArg(), "teacher").
+			WillReturnError(fmt.Errorf("audit error"))
+		mock.ExpectRollback()
+
+		err = dr.AddTemplate(WithOnBehalfOf(WithActor(ctx, "admin"), "teacher"), name, content)
+		assert.ErrorContains(t, err, "audit error")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
 	t.Run("Check inserting into template and template_content tables (wrong content)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -72,13 +133,13 @@ func Test_DirectRegistry_AddTemplate(t *testing.T) {
 
 		name := "Test name"
 		content := "Test content"
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.ExpectBegin()
 		mock.ExpectQuery("INSERT INTO template_content").WithArgs(content).
 			WillReturnError(fmt.Errorf("content error"))
 		mock.ExpectRollback()
 
-		err = dr.AddTemplate(name, content)
+		err = dr.AddTemplate(ctx, name, content)
 		assert.ErrorContains(t, err, "content error")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
@@ -86,6 +147,7 @@ func Test_DirectRegistry_AddTemplate(t *testing.T) {
 }
 
 func Test_DirectRegistry_ListTemplates(t *testing.T) {
+	ctx := context.Background()
 	t.Run("Check retrieving template names from template table (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -93,15 +155,37 @@ func Test_DirectRegistry_ListTemplates(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
+		rows := mock.NewRows([]string{"name"})
+		expNames := []string{"Test name 1", "Test name 1", "Test name 3"}
+		for _, name := range expNames {
+			rows.AddRow(name)
+		}
+		mock.ExpectQuery("SELECT name FROM template WHERE deleted_at IS NULL").WillReturnRows(rows)
+
+		templates, err := dr.ListTemplates(ctx, false)
+		assert.ElementsMatch(t, templates, expNames)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check retrieving template names including deleted ones (no errors)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
 		rows := mock.NewRows([]string{"name"})
 		expNames := []string{"Test name 1", "Test name 1", "Test name 3"}
 		for _, name := range expNames {
 			rows.AddRow(name)
 		}
-		mock.ExpectQuery("SELECT name FROM").WillReturnRows(rows)
+		mock.ExpectQuery("^SELECT name FROM template$").WillReturnRows(rows)
 
-		templates, err := dr.ListTemplates()
+		templates, err := dr.ListTemplates(ctx, true)
 		assert.ElementsMatch(t, templates, expNames)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
@@ -115,7 +199,7 @@ func Test_DirectRegistry_ListTemplates(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := mock.NewRows([]string{"name"})
 		expNames := []string{"Test name 1", "Test name 1", "Test name 3"}
 		for _, name := range expNames {
@@ -124,7 +208,7 @@ func Test_DirectRegistry_ListTemplates(t *testing.T) {
 		mock.ExpectQuery("SELECT name FROM").
 			WillReturnError(fmt.Errorf("names list error"))
 
-		templates, err := dr.ListTemplates()
+		templates, err := dr.ListTemplates(ctx, false)
 		assert.Nil(t, templates)
 		assert.ErrorContains(t, err, "names list error")
 		err = mock.ExpectationsWereMet()
@@ -133,59 +217,76 @@ func Test_DirectRegistry_ListTemplates(t *testing.T) {
 }
 
 func Test_DirectRegistry_DeleteTemplate(t *testing.T) {
-	t.Run("Check deleting from template and template_content tables (no errors)", func(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Check marking template as deleted (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening mock", err)
 		}
 		defer mock.Close()
 
-		id := 1
 		pk := 1
-		dr := &DirectRegistry{mock}
-		rows := pgxmock.NewRows([]string{"content"}).AddRow(id)
+		dr := &DirectRegistry{p: mock}
 		mock.ExpectBegin()
-		mock.ExpectQuery("SELECT content FROM template").WithArgs(pk).
-			WillReturnRows(rows)
-		mock.ExpectExec("DELETE FROM template").WithArgs(pk).
-			WillReturnResult(pgxmock.NewResult("DELETE", 1))
-		mock.ExpectExec("DELETE FROM template_content").WithArgs(id).
-			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		expectTemplateSelect(mock, pk, "name", "content")
+		mock.ExpectQuery("SELECT count").WithArgs(pk).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec("UPDATE template SET deleted_at").WithArgs(pk).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectAudit(mock, AuditDelete, AuditTemplate, "1")
 		mock.ExpectCommit()
 
-		err = dr.DeleteTemplate(pk)
+		err = dr.DeleteTemplate(ctx, pk)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 
-	t.Run("Check deleting from template and template_content tables (wrong id)", func(t *testing.T) {
+	t.Run("Check marking template as deleted (used by certificates)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening mock", err)
 		}
 		defer mock.Close()
 
-		id := 1
 		pk := 1
-		dr := &DirectRegistry{mock}
-		rows := pgxmock.NewRows([]string{"content"}).AddRow(id)
+		dr := &DirectRegistry{p: mock}
 		mock.ExpectBegin()
-		mock.ExpectQuery("SELECT content FROM template").WithArgs(pk).
-			WillReturnRows(rows)
-		mock.ExpectExec("DELETE FROM template").WithArgs(pk).
-			WillReturnResult(pgxmock.NewResult("DELETE", 1))
-		mock.ExpectExec("DELETE FROM template_content").WithArgs(pgxmock.AnyArg()).
+		expectTemplateSelect(mock, pk, "name", "content")
+		mock.ExpectQuery("SELECT count").WithArgs(pk).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectRollback()
+
+		err = dr.DeleteTemplate(ctx, pk)
+		assert.ErrorContains(t, err, "is used by 2 certificates")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check marking template as deleted (wrong id)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		pk := 1
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectTemplateSelect(mock, pk, "name", "content")
+		mock.ExpectQuery("SELECT count").WithArgs(pk).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec("UPDATE template SET deleted_at").WithArgs(pk).
 			WillReturnError(fmt.Errorf("id error"))
 		mock.ExpectRollback()
 
-		err = dr.DeleteTemplate(pk)
+		err = dr.DeleteTemplate(ctx, pk)
 		assert.ErrorContains(t, err, "id error")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 
-	t.Run("Check deleting from template and template_content tables (wrong template name)", func(t *testing.T) {
+	t.Run("Check marking template as deleted (wrong template name)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening mock", err)
@@ -193,20 +294,66 @@ func Test_DirectRegistry_DeleteTemplate(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.ExpectBegin()
-		mock.ExpectQuery("SELECT content FROM template").WithArgs(pgxmock.AnyArg()).
+		mock.ExpectQuery("SELECT template.name").WithArgs(pgxmock.AnyArg()).
 			WillReturnError(fmt.Errorf("template name error"))
 		mock.ExpectRollback()
 
-		err = dr.DeleteTemplate(pk)
+		err = dr.DeleteTemplate(ctx, pk)
 		assert.ErrorContains(t, err, "template name error")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 }
 
+func Test_DirectRegistry_RestoreTemplate(t *testing.T) {
+	ctx := context.Background()
+	name := "Test name"
+	t.Run("Check restoring deleted template (no errors)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		pk := 1
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE template SET deleted_at=NULL").WithArgs(name).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(pk))
+		expectTemplateSelect(mock, pk, name, "content")
+		expectAudit(mock, AuditRestore, AuditTemplate, "1")
+		mock.ExpectCommit()
+
+		err = dr.RestoreTemplate(ctx, name)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check restoring deleted template (no deleted template)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE template SET deleted_at=NULL").WithArgs(name).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		err = dr.RestoreTemplate(ctx, name)
+		assert.ErrorIs(t, err, ErrNotFound)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
 func Test_DirectRegistry_GetTemplatePK(t *testing.T) {
+	ctx := context.Background()
 	t.Run("Check retrieving template id from template table (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -215,12 +362,12 @@ func Test_DirectRegistry_GetTemplatePK(t *testing.T) {
 		defer mock.Close()
 
 		name := "Test name"
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := pgxmock.NewRows([]string{"id"}).AddRow(1)
 		mock.ExpectQuery("SELECT id FROM").WithArgs(name).
 			WillReturnRows(rows)
 
-		pk, err := dr.GetTemplatePK(name)
+		pk, err := dr.GetTemplatePK(ctx, name)
 		assert.NotZero(t, pk)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
@@ -235,11 +382,11 @@ func Test_DirectRegistry_GetTemplatePK(t *testing.T) {
 		defer mock.Close()
 
 		name := "Test name"
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.ExpectQuery("SELECT id FROM").WithArgs(name).
 			WillReturnError(fmt.Errorf("name error"))
 
-		pk, err := dr.GetTemplatePK(name)
+		pk, err := dr.GetTemplatePK(ctx, name)
 		assert.Zero(t, pk)
 		assert.ErrorContains(t, err, "name error")
 		err = mock.ExpectationsWereMet()
@@ -248,6 +395,7 @@ func Test_DirectRegistry_GetTemplatePK(t *testing.T) {
 }
 
 func Test_DirectRegistry_GetTemplateContent(t *testing.T) {
+	ctx := context.Background()
 	t.Run("Check retrieving template content from template_content table (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -256,7 +404,7 @@ func Test_DirectRegistry_GetTemplateContent(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows1 := pgxmock.NewRows([]string{"content"}).AddRow(1)
 		mock.ExpectQuery("SELECT content FROM").WithArgs(pk).
 			WillReturnRows(rows1)
@@ -264,7 +412,7 @@ func Test_DirectRegistry_GetTemplateContent(t *testing.T) {
 		mock.ExpectQuery("SELECT content FROM").WithArgs(pk).
 			WillReturnRows(rows2)
 
-		content, err := dr.GetTemplateContent(pk)
+		content, err := dr.GetTemplateContent(ctx, pk)
 		assert.NotEqual(t, content, nil)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
@@ -279,11 +427,11 @@ func Test_DirectRegistry_GetTemplateContent(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.ExpectQuery("SELECT content FROM").WithArgs(pgxmock.AnyArg()).
 			WillReturnError(fmt.Errorf("template id error"))
 
-		content, err := dr.GetTemplateContent(pk)
+		content, err := dr.GetTemplateContent(ctx, pk)
 		assert.Nil(t, content)
 		assert.ErrorContains(t, err, "template id error")
 		err = mock.ExpectationsWereMet()
@@ -298,14 +446,14 @@ func Test_DirectRegistry_GetTemplateContent(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows1 := pgxmock.NewRows([]string{"content"}).AddRow(1)
 		mock.ExpectQuery("SELECT content FROM").WithArgs(pk).
 			WillReturnRows(rows1)
 		mock.ExpectQuery("SELECT content FROM").WithArgs(pgxmock.AnyArg()).
 			WillReturnError(fmt.Errorf("template_content id error"))
 
-		content, err := dr.GetTemplateContent(pk)
+		content, err := dr.GetTemplateContent(ctx, pk)
 		assert.Nil(t, content)
 		assert.ErrorContains(t, err, "template_content id error")
 		err = mock.ExpectationsWereMet()
@@ -313,7 +461,71 @@ func Test_DirectRegistry_GetTemplateContent(t *testing.T) {
 	})
 }
 
+func Test_DirectRegistry_GetTemplate(t *testing.T) {
+	ctx := context.Background()
+	pk := 1
+	exp := &Template{Pk: pk, Name: "name", CreatedAt: time.Now().Add(-time.Hour), UpdatedAt: time.Now(),
+		CertificateCount: 2, ContentHash: "36a9e7f1c95b82ffb99743e0c5c4ce95d83c9a430aac59f84ef3cbfab6145068"}
+	columns := []string{"name", "created_at", "updated_at", "count", "encode"}
+	t.Run("Check retrieving template details (no errors)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		rows := pgxmock.NewRows(columns).
+			AddRow(exp.Name, exp.CreatedAt, exp.UpdatedAt, exp.CertificateCount, exp.ContentHash)
+		mock.ExpectQuery("SELECT template.name, template.created_at, template.updated_at").WithArgs(pk).
+			WillReturnRows(rows)
+
+		got, err := dr.GetTemplate(ctx, pk)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, got)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check retrieving template details (template not found)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectQuery("SELECT template.name").WithArgs(pk).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		got, err := dr.GetTemplate(ctx, pk)
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, ErrNotFound)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check retrieving template details (query error)", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectQuery("SELECT template.name").WithArgs(pk).
+			WillReturnError(fmt.Errorf("some error"))
+
+		got, err := dr.GetTemplate(ctx, pk)
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, "some error")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
 func Test_DirectRegistry_UpdateTemplate(t *testing.T) {
+	ctx := context.Background()
 	t.Run("Check updating template and template_content tables (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -322,16 +534,19 @@ func Test_DirectRegistry_UpdateTemplate(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.MatchExpectationsInOrder(false)
 		mock.ExpectBegin()
+		expectTemplateSelect(mock, pk, "name", "content")
 		mock.ExpectExec("UPDATE template").WithArgs("new name", pk).
 			WillReturnResult(pgxmock.NewResult("DELETE", 1))
 		mock.ExpectExec("UPDATE template_content").WithArgs("new content", pk).
 			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		expectTemplateSelect(mock, pk, "new name", "new content")
+		expectAudit(mock, AuditUpdate, AuditTemplate, "1")
 		mock.ExpectCommit()
 
-		err = dr.UpdateTemplate(pk, map[string]string{"name": "new name", "content": "new content"})
+		err = dr.UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name"), Content: Set("new content")})
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
@@ -345,14 +560,15 @@ func Test_DirectRegistry_UpdateTemplate(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.MatchExpectationsInOrder(false)
 		mock.ExpectBegin()
+		expectTemplateSelect(mock, pk, "name", "content")
 		mock.ExpectExec("UPDATE template").WithArgs("new name", pk).
 			WillReturnError(fmt.Errorf("no row found to UPDATE template"))
 		mock.ExpectRollback()
 
-		err = dr.UpdateTemplate(pk, map[string]string{"name": "new name"})
+		err = dr.UpdateTemplate(ctx, pk, TemplateUpdate{Name: Set("new name")})
 		assert.ErrorContains(t, err, "no row found to UPDATE template")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
@@ -366,20 +582,21 @@ func Test_DirectRegistry_UpdateTemplate(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		mock.MatchExpectationsInOrder(false)
 		mock.ExpectBegin()
+		expectTemplateSelect(mock, pk, "name", "content")
 		mock.ExpectExec("UPDATE template_content").WithArgs("new content", pk).
 			WillReturnError(fmt.Errorf("no row found to UPDATE template_content"))
 		mock.ExpectRollback()
 
-		err = dr.UpdateTemplate(pk, map[string]string{"content": "new content"})
+		err = dr.UpdateTemplate(ctx, pk, TemplateUpdate{Content: Set("new content")})
 		assert.ErrorContains(t, err, "no row found to UPDATE template_content")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 
-	t.Run("Check updating template and template_content tables (attempting to update an unknown field)", func(t *testing.T) {
+	t.Run("Check updating template and template_content tables (no fields to update)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening mock", err)
@@ -387,26 +604,30 @@ func Test_DirectRegistry_UpdateTemplate(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
-		mock.ExpectBegin()
-		mock.ExpectRollback()
-		err = dr.UpdateTemplate(pk, map[string]string{"mistake key": "mistake value"})
-		assert.ErrorContains(t, err, "illegal key in a map")
+		dr := &DirectRegistry{p: mock}
+		// There is no need to add mock.ExpectBegin, error returns earlier
+		err = dr.UpdateTemplate(ctx, pk, TemplateUpdate{Name: Field[string]{Value: "not set"}})
+		assert.ErrorContains(t, err, "no fields to update")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 }
 
 func Test_DirectRegistry_AddCertificate(t *testing.T) {
+	ctx := context.Background()
 	var (
 		templateName = "test template"
-		id           = "1"
+		id           = "1d28bdcd"
 		template_pk  = 1
 		timestamp    = time.Now()
 		student      = "test student"
 		issueDate    = "test issue date"
 		course       = "test course"
 		mentors      = "test mentors"
+		validFrom    = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		validUntil   = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		noTime       *time.Time
+		noId         *string
 	)
 
 	t.Run("Check inserting into certificate table", func(t *testing.T) {
@@ -416,14 +637,19 @@ func Test_DirectRegistry_AddCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := pgxmock.NewRows([]string{"id"}).AddRow(template_pk)
+		mock.ExpectBegin()
 		mock.ExpectQuery("SELECT id FROM template").WithArgs(templateName).WillReturnRows(rows)
 		rows = pgxmock.NewRows([]string{"id", "timestamp"}).AddRow(id, timestamp)
-		mock.ExpectQuery("INSERT INTO certificate").WithArgs(template_pk, student, issueDate, course, mentors).WillReturnRows(rows)
+		mock.ExpectQuery("INSERT INTO certificate").
+			WithArgs(noId, template_pk, student, issueDate, course, mentors, &validFrom, &validUntil).WillReturnRows(rows)
+		expectAudit(mock, AuditCreate, AuditCertificate, id)
+		mock.ExpectCommit()
 
-		cert, err := dr.AddCertificate(templateName, student, issueDate, course, mentors)
-		assert.Equal(t, &Certificate{id, template_pk, timestamp, student, issueDate, course, mentors}, cert)
+		cert, err := dr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, &validFrom, &validUntil)
+		assert.Equal(t, &Certificate{Id: id, TemplatePk: template_pk, Timestamp: timestamp, Student: student,
+			IssueDate: issueDate, Course: course, Mentors: mentors, ValidFrom: &validFrom, ValidUntil: &validUntil}, cert)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
@@ -437,10 +663,12 @@ func Test_DirectRegistry_AddCertificate(t *testing.T) {
 		defer mock.Close()
 
 		rows := pgxmock.NewRows([]string{"id"})
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
 		mock.ExpectQuery("SELECT id FROM template").WithArgs(templateName).WillReturnRows(rows)
+		mock.ExpectRollback()
 
-		cert, err := dr.AddCertificate(templateName, student, issueDate, course, mentors)
+		cert, err := dr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, noTime, noTime)
 		assert.Nil(t, cert)
 		assert.Error(t, err)
 		err = mock.ExpectationsWereMet()
@@ -454,13 +682,70 @@ func Test_DirectRegistry_AddCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := pgxmock.NewRows([]string{"id"}).AddRow(template_pk)
+		mock.ExpectBegin()
 		mock.ExpectQuery("SELECT id FROM template").WithArgs(templateName).WillReturnRows(rows)
 		rows = pgxmock.NewRows([]string{"id", "timestamp"})
-		mock.ExpectQuery("INSERT INTO certificate").WithArgs(template_pk, student, issueDate, course, mentors).WillReturnRows(rows)
+		mock.ExpectQuery("INSERT INTO certificate").
+			WithArgs(noId, template_pk, student, issueDate, course, mentors, noTime, noTime).WillReturnRows(rows)
+		mock.ExpectRollback()
 
-		cert, err := dr.AddCertificate(templateName, student, issueDate, course, mentors)
+		cert, err := dr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, noTime, noTime)
+		assert.Nil(t, cert)
+		assert.Error(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check retrying insert with new id on collision", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		ids := &HexIdGenerator{16}
+		dr := &DirectRegistry{p: mock, ids: ids}
+		isNewId := pgxmock.AnyArg()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM template").WithArgs(templateName).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(template_pk))
+		mock.ExpectQuery("INSERT INTO certificate").
+			WithArgs(isNewId, template_pk, student, issueDate, course, mentors, noTime, noTime).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "timestamp"}))
+		mock.ExpectQuery("INSERT INTO certificate").
+			WithArgs(isNewId, template_pk, student, issueDate, course, mentors, noTime, noTime).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "timestamp"}).AddRow("0123456789abcdef", timestamp))
+		expectAudit(mock, AuditCreate, AuditCertificate, "0123456789abcdef")
+		mock.ExpectCommit()
+
+		cert, err := dr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, noTime, noTime)
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789abcdef", cert.Id)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when every generated id collides", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock, ids: &HexIdGenerator{16}}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM template").WithArgs(templateName).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(template_pk))
+		for i := 0; i < maxIdAttempts; i++ {
+			mock.ExpectQuery("INSERT INTO certificate").
+				WithArgs(pgxmock.AnyArg(), template_pk, student, issueDate, course, mentors, noTime, noTime).
+				WillReturnRows(pgxmock.NewRows([]string{"id", "timestamp"}))
+		}
+		mock.ExpectRollback()
+
+		cert, err := dr.AddCertificate(ctx, templateName, student, issueDate, course, mentors, noTime, noTime)
 		assert.Nil(t, cert)
 		assert.Error(t, err)
 		err = mock.ExpectationsWereMet()
@@ -469,7 +754,9 @@ func Test_DirectRegistry_AddCertificate(t *testing.T) {
 }
 
 func Test_DirectRegistry_DeleteCertificate(t *testing.T) {
-	var id = "1"
+	ctx := context.Background()
+	var id = "1d28bdcd"
+	cert := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now()}
 
 	t.Run("Check deleting from certificate table", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
@@ -478,10 +765,14 @@ func Test_DirectRegistry_DeleteCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("DELETE FROM certificate").WithArgs(id).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectExec("UPDATE certificate SET deleted_at").WithArgs(id).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectAudit(mock, AuditDelete, AuditCertificate, id)
+		mock.ExpectCommit()
 
-		err = dr.DeleteCertificate(id)
+		err = dr.DeleteCertificate(ctx, id)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err, "there were unfulfilled expectations")
@@ -494,10 +785,13 @@ func Test_DirectRegistry_DeleteCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("DELETE FROM certificate").WithArgs(id).WillReturnError(fmt.Errorf("some error"))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectExec("UPDATE certificate SET deleted_at").WithArgs(id).WillReturnError(fmt.Errorf("some error"))
+		mock.ExpectRollback()
 
-		err = dr.DeleteCertificate(id)
+		err = dr.DeleteCertificate(ctx, id)
 		assert.Error(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err, "there were unfulfilled expectations")
@@ -510,10 +804,13 @@ func Test_DirectRegistry_DeleteCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("DELETE FROM certificate").WithArgs(id).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectExec("UPDATE certificate SET deleted_at").WithArgs(id).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectRollback()
 
-		err = dr.DeleteCertificate(id)
+		err = dr.DeleteCertificate(ctx, id)
 		assert.Error(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err, "there were unfulfilled expectations")
@@ -521,8 +818,55 @@ func Test_DirectRegistry_DeleteCertificate(t *testing.T) {
 
 }
 
+func Test_DirectRegistry_RestoreCertificate(t *testing.T) {
+	ctx := context.Background()
+	var id = "1d28bdcd"
+	cert := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now()}
+
+	t.Run("Check restoring deleted certificate", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE certificate SET deleted_at=NULL").WithArgs(id).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectCertificateSelect(mock, cert)
+		expectAudit(mock, AuditRestore, AuditCertificate, id)
+		mock.ExpectCommit()
+
+		err = dr.RestoreCertificate(ctx, id)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when there is no deleted certificate with live template", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE certificate SET deleted_at=NULL").WithArgs(id).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectRollback()
+
+		err = dr.RestoreCertificate(ctx, id)
+		assert.ErrorIs(t, err, ErrNotFound)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err, "there were unfulfilled expectations")
+	})
+}
+
 func Test_DirectRegistry_GetCertificate(t *testing.T) {
-	var id = "1"
+	ctx := context.Background()
+	var id = "1d28bdcd"
 	t.Run("Check getting certificate by Id", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -536,13 +880,107 @@ func Test_DirectRegistry_GetCertificate(t *testing.T) {
 		issueDate := "test issue date"
 		course := "test course"
 		mentors := "test mentors"
-		dr := &DirectRegistry{mock}
-		rows := pgxmock.NewRows([]string{"template", "timestamp", "student", "issue_date", "course", "mentors"}).
-			AddRow(template, timestamp, student, issueDate, course, mentors)
+		dr := &DirectRegistry{p: mock}
+		rows := pgxmock.NewRows(certificateColumns).
+			AddRow(template, timestamp, student, issueDate, course, mentors, nil, nil, nil, nil)
 		mock.ExpectQuery("SELECT template, timestamp, student, issue_date, course, mentors").WithArgs(id).WillReturnRows(rows)
 
-		cert, err := dr.GetCertificate(id)
-		assert.Equal(t, &Certificate{id, template, timestamp, student, issueDate, course, mentors}, cert)
+		cert, err := dr.GetCertificate(ctx, id)
+		assert.Equal(t, &Certificate{Id: id, TemplatePk: template, Timestamp: timestamp, Student: student,
+			IssueDate: issueDate, Course: course, Mentors: mentors}, cert)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check getting revoked certificate by Id", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		revokedAt := time.Now()
+		exp := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now(), RevokedAt: &revokedAt, RevocationReason: "misconduct"}
+		dr := &DirectRegistry{p: mock}
+		expectCertificateSelect(mock, exp)
+
+		cert, err := dr.GetCertificate(ctx, id)
+		assert.Equal(t, exp, cert)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check getting certificate with validity window by Id", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		validFrom := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		validUntil := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		exp := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now(), ValidFrom: &validFrom, ValidUntil: &validUntil}
+		dr := &DirectRegistry{p: mock}
+		expectCertificateSelect(mock, exp)
+
+		cert, err := dr.GetCertificate(ctx, id)
+		assert.Equal(t, exp, cert)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error without querying database for malformed Id", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock, ids: &HexIdGenerator{16}}
+		for _, malformed := range []string{"", "1", "1D28BDCD", "1d28bdcd'; --", "0123456789abcde"} {
+			cert, err := dr.GetCertificate(ctx, malformed)
+			assert.Nil(t, cert)
+			assert.ErrorIs(t, err, ErrInvalidId)
+		}
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check getting certificate by Id of configured format", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		exp := &Certificate{Id: "0123456789abcdef", TemplatePk: 1, Timestamp: time.Now()}
+		dr := &DirectRegistry{p: mock, ids: &HexIdGenerator{16}}
+		expectCertificateSelect(mock, exp)
+
+		cert, err := dr.GetCertificate(ctx, exp.Id)
+		assert.Equal(t, exp, cert)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check getting certificate by human Id typed in lower case", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		ids, _ := NewHumanIdGenerator("GUS", 1)
+		exp := &Certificate{Id: "GUS-2022-7K3F-" + string(checkChar("2022-7K3F")), TemplatePk: 1, Timestamp: time.Now()}
+		dr := &DirectRegistry{p: mock, ids: ids}
+		expectCertificateSelect(mock, exp)
+
+		cert, err := dr.GetCertificate(ctx, strings.ToLower(exp.Id))
+		assert.Equal(t, exp, cert)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
@@ -555,19 +993,93 @@ func Test_DirectRegistry_GetCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		rows := pgxmock.NewRows([]string{"template", "timestamp", "student", "issue_date", "course", "mentors"})
+		dr := &DirectRegistry{p: mock}
+		rows := pgxmock.NewRows(certificateColumns)
 		mock.ExpectQuery("SELECT template, timestamp").WithArgs(id).WillReturnRows(rows)
 
-		cert, err := dr.GetCertificate(id)
+		cert, err := dr.GetCertificate(ctx, id)
 		assert.Nil(t, cert)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, ErrNotFound)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
+func Test_DirectRegistry_ListExpiringCertificates(t *testing.T) {
+	ctx := context.Background()
+	within := 30 * 24 * time.Hour
+	t.Run("Check listing certificates expiring within given duration", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		validUntil := time.Now().Add(time.Hour)
+		exp := []*Certificate{
+			{Id: "1", TemplatePk: 1, Timestamp: time.Now(), Student: "student 1", ValidUntil: &validUntil},
+			{Id: "2", TemplatePk: 2, Timestamp: time.Now(), Student: "student 2", ValidUntil: &validUntil},
+		}
+		rows := pgxmock.NewRows(append([]string{"id"}, certificateColumns...))
+		for _, c := range exp {
+			rows.AddRow(c.Id, c.TemplatePk, c.Timestamp, c.Student, c.IssueDate, c.Course, c.Mentors,
+				nil, nil, nil, c.ValidUntil)
+		}
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectQuery(`valid_until >= now\(\) AND valid_until < now\(\) \+ make_interval\(secs => \$1\)`).
+			WithArgs(within.Seconds()).WillReturnRows(rows)
+
+		certs, err := dr.ListExpiringCertificates(ctx, within)
+		assert.Equal(t, exp, certs)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when it is unable to select from certificate table", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectQuery("SELECT id, template, timestamp").WithArgs(pgxmock.AnyArg()).
+			WillReturnError(fmt.Errorf("some error"))
+
+		certs, err := dr.ListExpiringCertificates(ctx, within)
+		assert.Nil(t, certs)
+		assert.ErrorContains(t, err, "some error")
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 }
 
+func Test_Certificate_Validity(t *testing.T) {
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		cert        Certificate
+		at          time.Time
+		expired     bool
+		notYetValid bool
+	}{
+		{"Unbounded window", Certificate{}, until, false, false},
+		{"Inside window", Certificate{ValidFrom: &from, ValidUntil: &until}, from, false, false},
+		{"Before window", Certificate{ValidFrom: &from, ValidUntil: &until}, from.Add(-time.Second), false, true},
+		{"End of window", Certificate{ValidFrom: &from, ValidUntil: &until}, until, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.cert.ExpiredAt(tt.at))
+			assert.Equal(t, tt.notYetValid, tt.cert.NotYetValidAt(tt.at))
+		})
+	}
+}
+
 func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
+	ctx := context.Background()
 	t.Run("Check retrieving template pks from certificate table (no errors)", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -576,7 +1088,7 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := mock.NewRows([]string{"id"})
 		expIds := []string{"1", "2", "3"}
 		for _, id := range expIds {
@@ -584,7 +1096,7 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 		}
 		mock.ExpectQuery("SELECT id FROM").WithArgs(pk).WillReturnRows(rows)
 
-		ids, err := dr.CertificatesByTemplatePK(pk)
+		ids, err := dr.CertificatesByTemplatePK(ctx, pk)
 		assert.ElementsMatch(t, ids, expIds)
 		assert.NoError(t, err)
 		err = mock.ExpectationsWereMet()
@@ -599,7 +1111,7 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := mock.NewRows([]string{"id"})
 		expIds := []string{"1", "2", "3"}
 		for _, id := range expIds {
@@ -607,7 +1119,7 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 		}
 		mock.ExpectQuery("SELECT id FROM").WithArgs(pk).WillReturnRows(&pgxmock.Rows{})
 
-		ids, err := dr.CertificatesByTemplatePK(pk)
+		ids, err := dr.CertificatesByTemplatePK(ctx, pk)
 		assert.NotEqualValues(t, ids, expIds)
 		assert.Nil(t, err)
 		err = mock.ExpectationsWereMet()
@@ -622,7 +1134,7 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 		defer mock.Close()
 
 		pk := 1
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 		rows := mock.NewRows([]string{"id"})
 		expIds := []string{"1", "2", "3"}
 		for _, id := range expIds {
@@ -631,7 +1143,7 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 		mock.ExpectQuery("SELECT id FROM").WithArgs(pk).
 			WillReturnError(fmt.Errorf("wrong certificate id's list"))
 
-		ids, err := dr.CertificatesByTemplatePK(pk)
+		ids, err := dr.CertificatesByTemplatePK(ctx, pk)
 		assert.Nil(t, ids)
 		assert.ErrorContains(t, err, "wrong certificate id's list")
 		err = mock.ExpectationsWereMet()
@@ -640,16 +1152,19 @@ func Test_DirectRegistry_CertificatesByTemplatePK(t *testing.T) {
 }
 
 func Test_DirectRegistry_UpdateCertificate(t *testing.T) {
+	ctx := context.Background()
 	var (
-		id = "1"
-		m  = map[string]string{
-			"template":   "test template",
-			"student":    "test student",
-			"issue_date": "test issue date",
-			"course":     "test course",
-			"mentors":    "test mentors",
+		id   = "1d28bdcd"
+		cert = &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now()}
+		u    = CertificateUpdate{
+			Template:  Set("test template"),
+			Student:   Set("test student"),
+			IssueDate: Set("test issue date"),
+			Course:    Set("test course"),
+			Mentors:   Set("test mentors"),
 		}
 	)
+	updated := time.Now()
 
 	t.Run("Check updating certificate table with one field", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
@@ -658,11 +1173,18 @@ func Test_DirectRegistry_UpdateCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("UPDATE certificate SET").WithArgs(id).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE certificate SET course=$2 WHERE id=$1 AND deleted_at IS NULL RETURNING timestamp")).
+			WithArgs(id, "test course").WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(updated))
+		expectCertificateSelect(mock, cert)
+		expectAudit(mock, AuditUpdate, AuditCertificate, id)
+		mock.ExpectCommit()
 
-		err = dr.UpdateCertificate(id, map[string]string{"course": "test course"})
+		got, err := dr.UpdateCertificate(ctx, id, CertificateUpdate{Course: Set("test course")})
 		assert.NoError(t, err)
+		assert.Equal(t, updated, got)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
@@ -674,15 +1196,90 @@ func Test_DirectRegistry_UpdateCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("UPDATE certificate SET").WithArgs(id).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery("SELECT id FROM template").WithArgs("test template").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE certificate SET student=$2, issue_date=$3, course=$4, mentors=$5, template=$6 WHERE id=$1")).
+			WithArgs(id, "test student", "test issue date", "test course", "test mentors", 2).
+			WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(updated))
+		expectCertificateSelect(mock, cert)
+		expectAudit(mock, AuditUpdate, AuditCertificate, id)
+		mock.ExpectCommit()
 
-		err = dr.UpdateCertificate(id, m)
+		got, err := dr.UpdateCertificate(ctx, id, u)
 		assert.NoError(t, err)
+		assert.Equal(t, updated, got)
 		err = mock.ExpectationsWereMet()
 		assert.NoErrorf(t, err, "there were unfulfilled expectations")
 	})
 
+	t.Run("Check values are passed as parameters, not interpolated", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		student := "O'Brien', course='hacked"
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE certificate SET student=$2 WHERE id=$1")).
+			WithArgs(id, student).WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(updated))
+		expectCertificateSelect(mock, cert)
+		expectAudit(mock, AuditUpdate, AuditCertificate, id)
+		mock.ExpectCommit()
+
+		_, err = dr.UpdateCertificate(ctx, id, CertificateUpdate{Student: Set(student)})
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check updating and removing validity bounds", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		validFrom := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE certificate SET valid_from=$2, valid_until=$3 WHERE id=$1")).
+			WithArgs(id, &validFrom, (*time.Time)(nil)).WillReturnRows(pgxmock.NewRows([]string{"timestamp"}).AddRow(updated))
+		expectCertificateSelect(mock, cert)
+		expectAudit(mock, AuditUpdate, AuditCertificate, id)
+		mock.ExpectCommit()
+
+		_, err = dr.UpdateCertificate(ctx, id, CertificateUpdate{ValidFrom: Set(&validFrom), ValidUntil: Set[*time.Time](nil)})
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when template with given name does not exist", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery("SELECT id FROM template").WithArgs("unknown").WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err = dr.UpdateCertificate(ctx, id, CertificateUpdate{Template: Set("unknown")})
+		assert.ErrorIs(t, err, ErrNotFound)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err, "there were unfulfilled expectations")
+	})
+
 	t.Run("Expecting error when it is unable to update certificate table", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -690,43 +1287,259 @@ func Test_DirectRegistry_UpdateCertificate(t *testing.T) {
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("UPDATE certificate SET").WithArgs(id).WillReturnError(fmt.Errorf("some error"))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery("UPDATE certificate SET").WithArgs(id, "test course").WillReturnError(fmt.Errorf("some error"))
+		mock.ExpectRollback()
 
-		err = dr.UpdateCertificate(id, m)
+		_, err = dr.UpdateCertificate(ctx, id, CertificateUpdate{Course: Set("test course")})
 		assert.Error(t, err)
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err, "there were unfulfilled expectations")
 
 	})
 
-	t.Run("Expecting error when there is no affected rows to update certificate table", func(t *testing.T) {
+	t.Run("Expecting error when there is no certificate to update", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening mock", err)
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
-		mock.ExpectExec("UPDATE certificate SET").WithArgs(id).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectQuery("UPDATE certificate SET").WithArgs(id, "test course").WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
 
-		err = dr.UpdateCertificate(id, m)
-		assert.Error(t, err)
+		_, err = dr.UpdateCertificate(ctx, id, CertificateUpdate{Course: Set("test course")})
+		assert.ErrorIs(t, err, ErrNotFound)
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err, "there were unfulfilled expectations")
 	})
 
-	t.Run("Expecting error when there is illegal key in map", func(t *testing.T) {
+	t.Run("Expecting error when there are no fields to update", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening mock", err)
 		}
 		defer mock.Close()
 
-		dr := &DirectRegistry{mock}
+		dr := &DirectRegistry{p: mock}
 
-		// There is no need to add mock.ExpectExec, error returns earlier
-		err = dr.UpdateCertificate(id, map[string]string{"illegal_key": "some value"})
-		assert.Error(t, err)
+		// There is no need to add mock.ExpectBegin, error returns earlier
+		_, err = dr.UpdateCertificate(ctx, id, CertificateUpdate{Course: Field[string]{Value: "not set"}})
+		assert.ErrorContains(t, err, "no fields to update")
 	})
 }
+
+func Test_DirectRegistry_RevokeCertificate(t *testing.T) {
+	ctx := context.Background()
+	id := "1d28bdcd"
+	reason := "misconduct"
+	revokedAt := time.Now()
+	cert := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now()}
+	revoked := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now(), RevokedAt: &revokedAt, RevocationReason: reason}
+
+	t.Run("Check revoking certificate", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectExec("UPDATE certificate SET revoked_at=now()").WithArgs(id, reason).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectCertificateSelect(mock, revoked)
+		expectAudit(mock, AuditRevoke, AuditCertificate, id)
+		mock.ExpectCommit()
+
+		err = dr.RevokeCertificate(ctx, id, reason)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when certificate is already revoked", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, revoked)
+		mock.ExpectRollback()
+
+		err = dr.RevokeCertificate(ctx, id, reason)
+		assert.ErrorContains(t, err, "already revoked")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when it is unable to update certificate table", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectExec("UPDATE certificate SET revoked_at=now()").WithArgs(id, reason).
+			WillReturnError(fmt.Errorf("some error"))
+		mock.ExpectRollback()
+
+		err = dr.RevokeCertificate(ctx, id, reason)
+		assert.ErrorContains(t, err, "some error")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
+func Test_DirectRegistry_UnrevokeCertificate(t *testing.T) {
+	ctx := context.Background()
+	id := "1d28bdcd"
+	revokedAt := time.Now()
+	cert := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now()}
+	revoked := &Certificate{Id: id, TemplatePk: 1, Timestamp: time.Now(), RevokedAt: &revokedAt, RevocationReason: "misconduct"}
+
+	t.Run("Check unrevoking certificate", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, revoked)
+		mock.ExpectExec("UPDATE certificate SET revoked_at=NULL").WithArgs(id).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectCertificateSelect(mock, cert)
+		expectAudit(mock, AuditUnrevoke, AuditCertificate, id)
+		mock.ExpectCommit()
+
+		err = dr.UnrevokeCertificate(ctx, id)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when certificate is not revoked", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		expectCertificateSelect(mock, cert)
+		mock.ExpectRollback()
+
+		err = dr.UnrevokeCertificate(ctx, id)
+		assert.ErrorContains(t, err, "not revoked")
+		err = mock.ExpectationsWereMet()
+		assert.NoErrorf(t, err, "there were unfulfilled expectations")
+	})
+}
+
+func Test_DirectRegistry_PurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	t.Run("Check purging deleted certificates and templates", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM certificate WHERE deleted_at").
+			WithArgs(before, "admin", AuditPurge, AuditCertificate).
+			WillReturnResult(pgxmock.NewResult("INSERT", 3))
+		mock.ExpectQuery("DELETE FROM template WHERE deleted_at").
+			WithArgs(before, "admin", AuditPurge, AuditTemplate).
+			WillReturnRows(pgxmock.NewRows([]string{"content"}).AddRow(4).AddRow(5))
+		mock.ExpectExec("DELETE FROM template_content").WithArgs([]int{4, 5}).
+			WillReturnResult(pgxmock.NewResult("DELETE", 2))
+		mock.ExpectCommit()
+
+		n, err := dr.PurgeDeleted(WithActor(ctx, "admin"), before)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, n)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Check purging when there are no deleted templates", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM certificate WHERE deleted_at").
+			WithArgs(before, AnonymousActor, AuditPurge, AuditCertificate).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectQuery("DELETE FROM template WHERE deleted_at").
+			WithArgs(before, AnonymousActor, AuditPurge, AuditTemplate).
+			WillReturnRows(pgxmock.NewRows([]string{"content"}))
+		mock.ExpectCommit()
+
+		n, err := dr.PurgeDeleted(ctx, before)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err, "there were unfulfilled expectations")
+	})
+
+	t.Run("Expecting error when it is unable to purge certificates", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening mock", err)
+		}
+		defer mock.Close()
+
+		dr := &DirectRegistry{p: mock}
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM certificate WHERE deleted_at").
+			WithArgs(before, AnonymousActor, AuditPurge, AuditCertificate).
+			WillReturnError(fmt.Errorf("some error"))
+		mock.ExpectRollback()
+
+		n, err := dr.PurgeDeleted(ctx, before)
+		assert.ErrorContains(t, err, "some error")
+		assert.Zero(t, n)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err, "there were unfulfilled expectations")
+	})
+}
+
+func Test_DirectRegistry_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool(pgxmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening mock", err)
+	}
+	defer mock.Close()
+
+	dr := &DirectRegistry{p: mock}
+	mock.ExpectPing()
+	assert.NoError(t, dr.CheckHealth(ctx))
+	mock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+	assert.ErrorContains(t, dr.CheckHealth(ctx), "failed to ping database: connection refused")
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err, "there were unfulfilled expectations")
+}
@@ -1,52 +1,168 @@
 package golangunitedschoolcerts
 
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
 	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Metadata key naming whom client acts for, REST clients pass it as X-Actor header.
+// It is sent by client, so it is recorded only as on behalf of, next to verified actor.
+const ActorMetadataKey = "x-actor"
+
+// Metadata key gateway passes REST client address in
+const forwardedForMetadataKey = "x-forwarded-for"
+
 type certsServer struct {
 	api.UnimplementedCertsServiceServer
 	r    Registry
 	s    Storage
 	t    Templater
 	host string
+	// Validity of URLs REST GetCertificate redirects to, zero disables redirects
+	signedURLTTL time.Duration
+	// Links point to share page, see LinkToSharePage
+	sharePage bool
+	// Nil unless credentials are issued, see IssueCredentials
+	credentials *CredentialSigner
+	issuer      Issuer
 }
 
 func NewCertsServer(r Registry, s Storage, t Templater, host string) *certsServer {
 	return &certsServer{r: r, s: s, t: t, host: host}
 }
 
+// REST GetCertificate of stored certificate redirects to URL signed by storage for ttl, when storage is URLSigner.
+// Gateway must be set up with RedirectMetadata and RedirectForwardResponse.
+func (s *certsServer) RedirectToSignedURLs(ttl time.Duration) {
+	s.signedURLTTL = ttl
+}
+
+// Attaches actor to context: subject of verified client certificate, falling back to client address.
+// Actor named in metadata is attached as on behalf of, it never replaces verified one.
+func ActorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	actor, ok := clientSubject(ctx)
+	if !ok {
+		actor = peerAddress(ctx)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(ActorMetadataKey); len(v) != 0 {
+			ctx = WithOnBehalfOf(ctx, v[0])
+		}
+	}
+	return handler(WithActor(ctx, actor), req)
+}
+
+// Address of client. For calls of in-process gateway it is the last address of X-Forwarded-For,
+// which gateway appends itself, earlier ones are sent by client.
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if p.Addr.Network() == gatewayNetwork {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(forwardedForMetadataKey); len(v) != 0 {
+				addrs := strings.Split(v[len(v)-1], ",")
+				return strings.TrimSpace(addrs[len(addrs)-1])
+			}
+		}
+	}
+	return p.Addr.String()
+}
+
+// Translates registry errors to gRPC status, so clients get NotFound and InvalidArgument instead of Unknown.
+// Other errors are returned as is.
+func statusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidId):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// Translates errors of unary methods with statusError
+func ErrorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, statusError(err)
+}
+
+// Translates errors of streaming methods with statusError
+func ErrorStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return statusError(handler(srv, ss))
+}
+
+// Forwards X-Actor header to gRPC metadata in addition to default ones.
+// Client subject can't be passed as header, it is set by gateway only for verified clients.
+func ActorHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, ActorMetadataKey) {
+		return ActorMetadataKey, true
+	}
+	if strings.EqualFold(key, runtime.MetadataHeaderPrefix+ClientSubjectMetadataKey) {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func (s *certsServer) AddTemplate(ctx context.Context, request *api.AddTemplateRequest) (*emptypb.Empty, error) {
-	return &emptypb.Empty{}, s.r.AddTemplate(request.GetName(), request.GetContent())
+	return &emptypb.Empty{}, s.r.AddTemplate(ctx, request.GetName(), request.GetContent())
 }
 
-func (s *certsServer) GetTemplate(ctx context.Context, request *api.GetTemplateRequest) (*api.GetTemplateResponse, error) {
-	i, err := s.r.GetTemplatePK(request.GetName())
+func (s *certsServer) GetTemplate(ctx context.Context, request *api.GetTemplateRequest) (*api.Template, error) {
+	pk, err := s.r.GetTemplatePK(ctx, request.GetName())
 	if err != nil {
 		return nil, err
 	}
-	c, err := s.r.GetTemplateContent(i)
-	if c != nil {
-		return &api.GetTemplateResponse{Content: *c}, nil
+	t, err := s.r.GetTemplate(ctx, pk)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	c, err := s.r.GetTemplateContent(ctx, pk)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Template{
+		Name:             t.Name,
+		Content:          *c,
+		CreatedAt:        timestamppb.New(t.CreatedAt),
+		UpdatedAt:        timestamppb.New(t.UpdatedAt),
+		CertificateCount: int64(t.CertificateCount),
+		ContentHash:      t.ContentHash,
+	}, nil
 }
 
 func (s *certsServer) DeleteTemplate(ctx context.Context, request *api.DeleteTemplateRequest) (*emptypb.Empty, error) {
-	pk, err := s.r.GetTemplatePK(request.GetName())
+	pk, err := s.r.GetTemplatePK(ctx, request.GetName())
 	if err != nil {
 		return nil, err
 	}
-	return &emptypb.Empty{}, s.r.DeleteTemplate(pk)
+	return &emptypb.Empty{}, s.r.DeleteTemplate(ctx, pk)
 }
 
-func (s *certsServer) ListTemplates(ctx context.Context, in *emptypb.Empty) (*api.ListTemplatesResponse, error) {
-	t, err := s.r.ListTemplates()
+func (s *certsServer) ListTemplates(ctx context.Context, request *api.ListTemplatesRequest) (*api.ListTemplatesResponse, error) {
+	t, err := s.r.ListTemplates(ctx, request.GetShowDeleted())
 	if err == nil {
 		return &api.ListTemplatesResponse{Names: t}, nil
 	}
@@ -54,115 +170,283 @@ func (s *certsServer) ListTemplates(ctx context.Context, in *emptypb.Empty) (*ap
 }
 
 func (s *certsServer) DeleteCertificate(ctx context.Context, request *api.DeleteCertificateRequest) (*emptypb.Empty, error) {
-	cert, err := s.r.GetCertificate(request.GetId())
+	cert, err := s.r.GetCertificate(ctx, request.GetId())
 	if err != nil {
 		return &emptypb.Empty{}, err
 	}
-	err = s.r.DeleteCertificate(request.GetId())
+	err = s.r.DeleteCertificate(ctx, cert.Id)
 	if err != nil {
 		return &emptypb.Empty{}, err
 	}
-	s.s.Delete(request.GetId(), cert.Timestamp)
+	s.deleteCertificateFiles(cert.Id, cert.Timestamp)
 	return &emptypb.Empty{}, nil
 }
 
 func (s *certsServer) UpdateTemplate(ctx context.Context, request *api.UpdateTemplateRequest) (*emptypb.Empty, error) {
-	pk, err := s.r.GetTemplatePK(request.GetName())
+	u, err := updateFromMask(request.GetTemplate(), request.GetUpdateMask(), templateMaskSetters, templateMaskIgnored...)
 	if err != nil {
 		return &emptypb.Empty{}, err
 	}
-	m := make(map[string]string)
-	if request.NewContent != nil {
-		m["content"] = request.GetNewContent()
-	}
-	if request.NewName != nil {
-		m["name"] = request.GetNewName()
-	}
-	if len(m) != 0 {
-		return &emptypb.Empty{}, s.r.UpdateTemplate(pk, m)
+	pk, err := s.r.GetTemplatePK(ctx, request.GetName())
+	if err != nil {
+		return &emptypb.Empty{}, err
 	}
-	return &emptypb.Empty{}, fmt.Errorf("no fields to update was provided")
+	return &emptypb.Empty{}, s.r.UpdateTemplate(ctx, pk, u)
 }
 
+// Link to certificate put into QR code and returned by API
 func (s *certsServer) composeCertificateLink(id string) string {
+	if s.sharePage {
+		return s.composeSharePageLink(id)
+	}
+	return s.composePDFLink(id)
+}
+
+func (s *certsServer) composePDFLink(id string) string {
 	return s.host + "certificate/" + id
 }
 
+// Link to certificate rendered as image variant
+func (s *certsServer) composeImageLink(id string, v Variant) string {
+	q := url.Values{"format": {string(v.Format)}, "width": {strconv.Itoa(v.Width)}}
+	return s.composePDFLink(id) + "?" + q.Encode()
+}
+
+// Certificate resource, referring template by name
+func (s *certsServer) certificateToProto(c *Certificate, template string) *api.Certificate {
+	return &api.Certificate{
+		Id:               c.Id,
+		Student:          c.Student,
+		IssueDate:        c.IssueDate,
+		Course:           c.Course,
+		Mentors:          c.Mentors,
+		ValidFrom:        timeToProto(c.ValidFrom),
+		ValidUntil:       timeToProto(c.ValidUntil),
+		Link:             s.composeCertificateLink(c.Id),
+		Template:         template,
+		Timestamp:        timestamppb.New(c.Timestamp),
+		RevokedAt:        timeToProto(c.RevokedAt),
+		RevocationReason: c.RevocationReason,
+	}
+}
+
 func (s *certsServer) GetCertificate(ctx context.Context, request *api.GetCertificateRequest) (*httpbody.HttpBody, error) {
-	cert, err := s.r.GetCertificate(request.GetId())
+	v, err := ParseVariant(request.GetFormat(), int(request.GetWidth()))
 	if err != nil {
 		return nil, err
 	}
-	if s.s.Contains(cert.Id, cert.Timestamp) {
-		pdf, err := s.s.Get(cert.Id, cert.Timestamp)
+	cert, err := s.issuedCertificate(ctx, request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	key := v.key(cert.Id)
+	if s.s.Contains(key, cert.Timestamp) {
+		if u := s.signedURL(ctx, cert, v); u != "" {
+			if err := grpc.SetHeader(ctx, metadata.Pairs(redirectLocationMetadataKey, u)); err != nil {
+				return nil, err
+			}
+			return &httpbody.HttpBody{}, nil
+		}
+		file, err := s.s.Get(key, cert.Timestamp)
 		if err != nil {
 			return nil, err
 		}
-		return &httpbody.HttpBody{ContentType: "application/pdf", Data: *pdf}, nil
+		return &httpbody.HttpBody{ContentType: v.ContentType(), Data: *file}, nil
 	}
-	template, err := s.r.GetTemplateContent(cert.TemplatePk)
+	file, err := s.generateCertificate(ctx, cert, v)
 	if err != nil {
 		return nil, err
 	}
-	pdf, err := s.t.GenerateCertificate(*template, cert, s.composeCertificateLink(cert.Id))
+	return &httpbody.HttpBody{ContentType: v.ContentType(), Data: *file}, nil
+}
+
+// Signed URL of stored certificate for REST request, empty when request isn't redirected.
+// Certificate is served by server when signing fails. URLs are signed for PDF only, as backend serves them as PDF.
+func (s *certsServer) signedURL(ctx context.Context, cert *Certificate, v Variant) string {
+	signer, ok := s.s.(URLSigner)
+	if !ok || v != PDF || s.signedURLTTL == 0 || !acceptsRedirect(ctx) {
+		return ""
+	}
+	u, err := signer.SignedURL(cert.Id, cert.Timestamp, s.signedURLTTL)
 	if err != nil {
-		return nil, err
+		log.Printf("Failed to sign URL of certificate %s: %v", cert.Id, err)
 	}
-	err = s.s.Add(cert.Id, cert.Timestamp, pdf)
+	return u
+}
+
+// Certificate which file may be served, revoked ones are refused
+func (s *certsServer) issuedCertificate(ctx context.Context, id string) (*Certificate, error) {
+	cert, err := s.r.GetCertificate(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &httpbody.HttpBody{ContentType: "application/pdf", Data: *pdf}, nil
+	if cert.RevokedAt != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "certificate %s was revoked: %s", cert.Id, cert.RevocationReason)
+	}
+	return cert, nil
 }
 
-func (s *certsServer) TestTemplate(ctx context.Context, request *api.TestTemplateRequest) (*httpbody.HttpBody, error) {
-	pk, err := s.r.GetTemplatePK(request.GetName())
+// Generates certificate file in variant and adds it to storage
+func (s *certsServer) generateCertificate(ctx context.Context, cert *Certificate, v Variant) (*[]byte, error) {
+	template, err := s.r.GetTemplateContent(ctx, cert.TemplatePk)
 	if err != nil {
 		return nil, err
 	}
-	template, err := s.r.GetTemplateContent(pk)
+	file, err := s.t.GenerateCertificate(*template, cert, s.composeCertificateLink(cert.Id), v)
 	if err != nil {
 		return nil, err
 	}
-	cert := Certificate{
-		Id:        request.GetCertificate().GetId(),
-		Student:   request.GetCertificate().GetStudent(),
-		IssueDate: request.GetCertificate().GetIssueDate(),
-		Course:    request.GetCertificate().GetCourse(),
-		Mentors:   request.GetCertificate().GetMentors(),
+	if err = s.s.Add(v.key(cert.Id), cert.Timestamp, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Deletes every stored variant of certificate
+func (s *certsServer) deleteCertificateFiles(id string, timestamp time.Time) {
+	for _, v := range variants() {
+		s.s.Delete(v.key(id), timestamp)
+	}
+}
+
+// Opens certificate file for streaming, generating it first when storage doesn't have it
+func (s *certsServer) openCertificate(ctx context.Context, id string) (*Certificate, io.ReadCloser, error) {
+	cert, err := s.issuedCertificate(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.s.Contains(cert.Id, cert.Timestamp) {
+		f, err := s.s.Open(cert.Id, cert.Timestamp)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cert, f, nil
+	}
+	pdf, err := s.generateCertificate(ctx, cert, PDF)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, bytesFile{bytes.NewReader(*pdf)}, nil
+}
+
+// Size of HttpBody chunks sent by DownloadCertificate
+const downloadChunkSize = 64 << 10
+
+func (s *certsServer) DownloadCertificate(request *api.DownloadCertificateRequest, stream api.CertsService_DownloadCertificateServer) error {
+	_, f, err := s.openCertificate(stream.Context(), request.GetId())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// Send copies chunk into message, so buffer is reused
+	buf := make([]byte, downloadChunkSize)
+	chunk := &httpbody.HttpBody{ContentType: "application/pdf"}
+	for {
+		n, err := io.ReadFull(f, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		// Empty file is still sent as chunk with content type
+		if n != 0 || chunk.ContentType != "" {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			chunk = &httpbody.HttpBody{}
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// REST handler of DownloadCertificate. http.ServeContent answers range and conditional requests,
+// certificate timestamp is used as Last-Modified and file checksum as ETag.
+// Errors are written by error handler of mux, the same way gateway does.
+func (s *certsServer) CertificateHandler(mux *runtime.ServeMux) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		cert, f, err := s.openCertificate(r.Context(), pathParams["id"])
+		if err != nil {
+			httpError(mux, w, r, err)
+			return
+		}
+		defer f.Close()
+		content, ok := f.(io.ReadSeeker)
+		if !ok {
+			b, err := io.ReadAll(f)
+			if err != nil {
+				httpError(mux, w, r, err)
+				return
+			}
+			content = bytes.NewReader(b)
+		}
+		if sum, ok := s.s.Checksum(cert.Id, cert.Timestamp); ok {
+			w.Header().Set("ETag", `"`+sum+`"`)
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		http.ServeContent(w, r, cert.Id+".pdf", cert.Timestamp, content)
+	}
+}
+
+// Writes error of handler registered by HandlePath with error handler of mux, translated with statusError
+func httpError(mux *runtime.ServeMux, w http.ResponseWriter, r *http.Request, err error) {
+	_, outbound := runtime.MarshalerForRequest(mux, r)
+	runtime.HTTPError(r.Context(), mux, outbound, w, r, statusError(err))
+}
+
+func (s *certsServer) GetCertificateMetadata(ctx context.Context, request *api.GetCertificateMetadataRequest) (*api.Certificate, error) {
+	cert, err := s.r.GetCertificate(ctx, request.GetId())
+	if err != nil {
+		return nil, err
 	}
-	pdf, err := s.t.GenerateCertificate(*template, &cert, s.composeCertificateLink(cert.Id))
+	t, err := s.r.GetTemplate(ctx, cert.TemplatePk)
 	if err != nil {
 		return nil, err
 	}
-	return &httpbody.HttpBody{ContentType: "application/pdf", Data: *pdf}, nil
+	return s.certificateToProto(cert, t.Name), nil
 }
 
-func (s *certsServer) UpdateCertificate(ctx context.Context, request *api.UpdateCertificateRequest) (*emptypb.Empty, error) {
-	m := make(map[string]string)
-	if request.NewTemplate != nil {
-		m["template"] = request.GetNewTemplate()
+func (s *certsServer) TestTemplate(ctx context.Context, request *api.TestTemplateRequest) (*httpbody.HttpBody, error) {
+	v, err := ParseVariant(request.GetFormat(), int(request.GetWidth()))
+	if err != nil {
+		return nil, err
 	}
-	if request.NewStudent != nil {
-		m["student"] = request.GetNewStudent()
+	pk, err := s.r.GetTemplatePK(ctx, request.GetName())
+	if err != nil {
+		return nil, err
 	}
-	if request.NewIssueDate != nil {
-		m["issue_date"] = request.GetNewIssueDate()
+	template, err := s.r.GetTemplateContent(ctx, pk)
+	if err != nil {
+		return nil, err
 	}
-	if request.NewCourse != nil {
-		m["course"] = request.GetNewCourse()
+	cert := Certificate{
+		Id:         request.GetCertificate().GetId(),
+		Student:    request.GetCertificate().GetStudent(),
+		IssueDate:  request.GetCertificate().GetIssueDate(),
+		Course:     request.GetCertificate().GetCourse(),
+		Mentors:    request.GetCertificate().GetMentors(),
+		ValidFrom:  timeFromProto(request.GetCertificate().GetValidFrom()),
+		ValidUntil: timeFromProto(request.GetCertificate().GetValidUntil()),
 	}
-	if request.NewMentors != nil {
-		m["mentors"] = request.GetNewMentors()
+	file, err := s.t.GenerateCertificate(*template, &cert, s.composeCertificateLink(cert.Id), v)
+	if err != nil {
+		return nil, err
 	}
-	if len(m) != 0 {
-		return &emptypb.Empty{}, s.r.UpdateCertificate(request.GetId(), m)
+	return &httpbody.HttpBody{ContentType: v.ContentType(), Data: *file}, nil
+}
+
+func (s *certsServer) UpdateCertificate(ctx context.Context, request *api.UpdateCertificateRequest) (*emptypb.Empty, error) {
+	u, err := updateFromMask(request.GetCertificate(), request.GetUpdateMask(), certificateMaskSetters, certificateMaskIgnored...)
+	if err != nil {
+		return &emptypb.Empty{}, err
 	}
-	return &emptypb.Empty{}, fmt.Errorf("no fields to update was provided")
+	_, err = s.r.UpdateCertificate(ctx, request.GetId(), u)
+	return &emptypb.Empty{}, err
 }
 
 func (s *certsServer) AddCertificate(ctx context.Context, request *api.AddCertificateRequest) (*api.AddCertificateResponse, error) {
-	cert, err := s.r.AddCertificate(request.GetTemplateName(), request.GetStudent(), request.GetIssueDate(), request.GetCourse(), request.GetMentors())
+	cert, err := s.r.AddCertificate(ctx, request.GetTemplateName(), request.GetStudent(), request.GetIssueDate(), request.GetCourse(), request.GetMentors(),
+		timeFromProto(request.GetValidFrom()), timeFromProto(request.GetValidUntil()))
 	if err != nil {
 		return nil, err
 	}
@@ -170,9 +454,138 @@ func (s *certsServer) AddCertificate(ctx context.Context, request *api.AddCertif
 }
 
 func (s *certsServer) GetCertificateLink(ctx context.Context, request *api.GetCertificateLinkRequest) (*api.GetCertificateLinkResponse, error) {
-	cert, err := s.r.GetCertificate(request.GetId())
+	cert, err := s.r.GetCertificate(ctx, request.GetId())
 	if err != nil {
 		return nil, err
 	}
 	return &api.GetCertificateLinkResponse{Link: s.composeCertificateLink(cert.Id)}, nil
 }
+
+func (s *certsServer) ListAuditEvents(ctx context.Context, request *api.ListAuditEventsRequest) (*api.ListAuditEventsResponse, error) {
+	f := AuditFilter{Entity: request.GetEntity(), EntityId: request.GetEntityId()}
+	if f.Entity != "" && f.Entity != AuditTemplate && f.Entity != AuditCertificate {
+		return nil, status.Errorf(codes.InvalidArgument, "entity must be %s or %s, got %q", AuditTemplate, AuditCertificate, f.Entity)
+	}
+	if request.From != nil {
+		f.From = request.GetFrom().AsTime()
+	}
+	if request.To != nil {
+		f.To = request.GetTo().AsTime()
+	}
+	events, err := s.r.ListAuditEvents(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.ListAuditEventsResponse{Events: make([]*api.AuditEvent, 0, len(events))}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &api.AuditEvent{
+			Id:         e.Id,
+			Time:       timestamppb.New(e.Time),
+			Actor:      e.Actor,
+			OnBehalfOf: e.OnBehalfOf,
+			Action:     e.Action,
+			Entity:     e.Entity,
+			EntityId:   e.EntityId,
+			Before:     string(e.Before),
+			After:      string(e.After),
+		})
+	}
+	return resp, nil
+}
+
+func (s *certsServer) RevokeCertificate(ctx context.Context, request *api.RevokeCertificateRequest) (*emptypb.Empty, error) {
+	cert, err := s.r.GetCertificate(ctx, request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	err = s.r.RevokeCertificate(ctx, cert.Id, request.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	s.deleteCertificateFiles(cert.Id, cert.Timestamp)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *certsServer) UnrevokeCertificate(ctx context.Context, request *api.UnrevokeCertificateRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, s.r.UnrevokeCertificate(ctx, request.GetId())
+}
+
+func (s *certsServer) VerifyCertificate(ctx context.Context, request *api.VerifyCertificateRequest) (*api.VerifyCertificateResponse, error) {
+	resp := &api.VerifyCertificateResponse{Id: request.GetId()}
+	cert, err := s.r.GetCertificate(ctx, request.GetId())
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrInvalidId):
+		resp.Status = api.VerifyCertificateResponse_NOT_FOUND
+		return resp, nil
+	case err != nil:
+		return nil, err
+	}
+	resp.Id = cert.Id
+	resp.ValidFrom = timeToProto(cert.ValidFrom)
+	resp.ValidUntil = timeToProto(cert.ValidUntil)
+	// Lets holders check their copy of PDF against the generated one
+	resp.Checksum, _ = s.s.Checksum(cert.Id, cert.Timestamp)
+	now := time.Now()
+	switch {
+	case cert.RevokedAt != nil:
+		resp.Status = api.VerifyCertificateResponse_REVOKED
+		resp.RevokedAt = timestamppb.New(*cert.RevokedAt)
+		resp.RevocationReason = cert.RevocationReason
+	case cert.ExpiredAt(now):
+		resp.Status = api.VerifyCertificateResponse_EXPIRED
+	case cert.NotYetValidAt(now):
+		resp.Status = api.VerifyCertificateResponse_NOT_YET_VALID
+	default:
+		resp.Status = api.VerifyCertificateResponse_VALID
+	}
+	return resp, nil
+}
+
+func (s *certsServer) RestoreTemplate(ctx context.Context, request *api.RestoreTemplateRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, s.r.RestoreTemplate(ctx, request.GetName())
+}
+
+func (s *certsServer) RestoreCertificate(ctx context.Context, request *api.RestoreCertificateRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, s.r.RestoreCertificate(ctx, request.GetId())
+}
+
+func (s *certsServer) ListExpiringCertificates(ctx context.Context, request *api.ListExpiringCertificatesRequest) (*api.ListExpiringCertificatesResponse, error) {
+	if !request.GetWithin().IsValid() || request.GetWithin().AsDuration() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "positive within duration is required")
+	}
+	certs, err := s.r.ListExpiringCertificates(ctx, request.GetWithin().AsDuration())
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.ListExpiringCertificatesResponse{Certificates: make([]*api.Certificate, 0, len(certs))}
+	templates := make(map[int]string)
+	for _, c := range certs {
+		name, ok := templates[c.TemplatePk]
+		if !ok {
+			t, err := s.r.GetTemplate(ctx, c.TemplatePk)
+			if err != nil {
+				return nil, err
+			}
+			name = t.Name
+			templates[c.TemplatePk] = name
+		}
+		resp.Certificates = append(resp.Certificates, s.certificateToProto(c, name))
+	}
+	return resp, nil
+}
+
+// Unset timestamp means unbounded validity window
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
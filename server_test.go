@@ -1,6 +1,7 @@
 package golangunitedschoolcerts
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -18,9 +19,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const host = "http://example.com/"
@@ -33,10 +42,13 @@ func initTestServerAndConn(t *testing.T, ctx context.Context) (rMock *MockRegist
 	bufSize := 1024 * 1024
 	lis := bufconn.Listen(bufSize)
 
-	s := grpc.NewServer()
-	api.RegisterCertsServiceServer(s, NewCertsServer(rMock, sMock, tMock, host))
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(ErrorUnaryInterceptor, ActorUnaryInterceptor),
+		grpc.StreamInterceptor(ErrorStreamInterceptor))
+	server := NewCertsServer(rMock, sMock, tMock, host)
+	api.RegisterCertsServiceServer(s, server)
 	go func() {
-		if err := s.Serve(lis); err != nil {
+		// REST tests served by handlers of mux may stop server before it starts serving
+		if err := s.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			log.Fatalf("unexpected server exited with error: %v", err)
 		}
 	}()
@@ -49,11 +61,15 @@ func initTestServerAndConn(t *testing.T, ctx context.Context) (rMock *MockRegist
 
 	client = api.NewCertsServiceClient(conn)
 
-	mux = runtime.NewServeMux()
+	mux = runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(ActorHeaderMatcher))
 	err = api.RegisterCertsServiceHandlerClient(ctx, mux, client)
 	if err != nil {
 		assert.FailNow(t, "unexpected error while registering service handler: %v", err)
 	}
+	err = mux.HandlePath(http.MethodGet, "/certificate/{id}/download", server.CertificateHandler(mux))
+	if err != nil {
+		assert.FailNow(t, "unexpected error while registering download handler: %v", err)
+	}
 
 	// automatically choosing open port
 	sRest := httptest.NewServer(mux)
@@ -66,6 +82,23 @@ func initTestServerAndConn(t *testing.T, ctx context.Context) (rMock *MockRegist
 	return rMock, sMock, tMock, client, closer, mux
 }
 
+// Server with storage signing URLs, REST gateway calls it in process
+func initTestRedirectingServer(t *testing.T, ctx context.Context) (rMock *MockRegistry, sMock *MockStorage, uMock *MockURLSigner, server *certsServer, mux *runtime.ServeMux) {
+	rMock = NewMockRegistry(t)
+	sMock = NewMockStorage(t)
+	uMock = NewMockURLSigner(t)
+	server = NewCertsServer(rMock, struct {
+		*MockStorage
+		*MockURLSigner
+	}{sMock, uMock}, nil, host)
+	server.RedirectToSignedURLs(time.Minute)
+	mux = runtime.NewServeMux(runtime.WithMetadata(RedirectMetadata), runtime.WithForwardResponseOption(RedirectForwardResponse))
+	if err := api.RegisterCertsServiceHandlerServer(ctx, mux, server); err != nil {
+		assert.FailNow(t, "unexpected error while registering service handler: %v", err)
+	}
+	return rMock, sMock, uMock, server, mux
+}
+
 func Test_AddTemplate(t *testing.T) {
 	name := "Name"
 	content := "Test content"
@@ -73,7 +106,7 @@ func Test_AddTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().AddTemplate(name, content).Return(nil)
+		rMock.EXPECT().AddTemplate(mock.Anything, name, content).Return(nil)
 		_, err := client.AddTemplate(ctx, &api.AddTemplateRequest{Name: name, Content: content})
 		assert.NoError(t, err)
 	})
@@ -81,7 +114,7 @@ func Test_AddTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().AddTemplate(name, content).Return(fmt.Errorf("Registry error"))
+		rMock.EXPECT().AddTemplate(mock.Anything, name, content).Return(fmt.Errorf("Registry error"))
 		_, err := client.AddTemplate(ctx, &api.AddTemplateRequest{Name: name, Content: content})
 		assert.ErrorContains(t, err, "Registry error")
 	})
@@ -91,7 +124,7 @@ func Test_AddTemplate(t *testing.T) {
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().AddTemplate(name, content).Return(nil)
+		rMock.EXPECT().AddTemplate(mock.Anything, name, content).Return(nil)
 		body := `{"name": ` + `"` + name + `", "content": ` + `"` + content + `"}`
 
 		req := httptest.NewRequest(http.MethodPost, "/template", strings.NewReader(body))
@@ -104,31 +137,49 @@ func Test_AddTemplate(t *testing.T) {
 
 func Test_GetTemplate(t *testing.T) {
 	name := "name"
+	tmpl := &Template{Pk: 1, Name: name, CreatedAt: time.Now().Add(-time.Hour).UTC(), UpdatedAt: time.Now().UTC(),
+		CertificateCount: 3, ContentHash: "36a9e7f1c95b82ffb99743e0c5c4ce95d83c9a430aac59f84ef3cbfab6145068"}
 	t.Run("Successful", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 		exp := " "
-		rMock.EXPECT().GetTemplatePK(name).Return(0, nil)
-		rMock.EXPECT().GetTemplateContent(0).Return(&exp, nil)
-		tpml, err := client.GetTemplate(ctx, &api.GetTemplateRequest{Name: name})
-		assert.Equal(t, exp, tpml.Content)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(1, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, 1).Return(&exp, nil)
+		got, err := client.GetTemplate(ctx, &api.GetTemplateRequest{Name: name})
 		assert.NoError(t, err)
+		assert.Equal(t, exp, got.GetContent())
+		assert.Equal(t, name, got.GetName())
+		assert.Equal(t, tmpl.CreatedAt, got.GetCreatedAt().AsTime())
+		assert.Equal(t, tmpl.UpdatedAt, got.GetUpdatedAt().AsTime())
+		assert.Equal(t, int64(3), got.GetCertificateCount())
+		assert.Equal(t, tmpl.ContentHash, got.GetContentHash())
 	})
 	t.Run("Registry returns error (name not found)", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(0, fmt.Errorf("Registry error (name not found)"))
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(0, fmt.Errorf("Registry error (name not found)"))
 		_, err := client.GetTemplate(ctx, &api.GetTemplateRequest{Name: name})
 		assert.ErrorContains(t, err, "name not found")
 	})
+	t.Run("Registry returns error (GetTemplate failed)", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(1, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(nil, fmt.Errorf("GetTemplate error"))
+		_, err := client.GetTemplate(ctx, &api.GetTemplateRequest{Name: name})
+		assert.ErrorContains(t, err, "GetTemplate error")
+	})
 	t.Run("Registry returns error (content not found)", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(0, nil)
-		rMock.EXPECT().GetTemplateContent(0).Return(nil, fmt.Errorf("Registry error (content not found)"))
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(1, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, 1).Return(nil, fmt.Errorf("Registry error (content not found)"))
 		_, err := client.GetTemplate(ctx, &api.GetTemplateRequest{Name: name})
 		assert.ErrorContains(t, err, "content not found")
 	})
@@ -137,8 +188,9 @@ func Test_GetTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(0, nil)
-		rMock.EXPECT().GetTemplateContent(0).Return(&exp, nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(1, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, 1).Return(&exp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/template/"+name, nil)
 		resp := httptest.NewRecorder()
@@ -150,12 +202,21 @@ func Test_GetTemplate(t *testing.T) {
 		if err != nil {
 			assert.FailNow(t, "failed to read response body: %v", err)
 		}
-		m := make(map[string]string)
+		m := make(map[string]any)
 		err = json.Unmarshal(tc, &m)
 		if err != nil {
 			assert.FailNow(t, "failed to unmarshal response: %v", err)
 		}
 		assert.Equal(t, exp, m["content"])
+		assert.Equal(t, "3", m["certificateCount"])
+		assert.Equal(t, tmpl.ContentHash, m["contentHash"])
+	})
+	t.Run("Content keeps field number of former GetTemplateResponse", func(t *testing.T) {
+		b, err := proto.Marshal(&api.Template{Content: "content"})
+		assert.NoError(t, err)
+		exp := protowire.AppendTag(nil, 1, protowire.BytesType)
+		exp = protowire.AppendString(exp, "content")
+		assert.Equal(t, exp, b)
 	})
 }
 
@@ -166,8 +227,8 @@ func Test_DeleteTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
-		rMock.EXPECT().DeleteTemplate(pk).Return(nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().DeleteTemplate(mock.Anything, pk).Return(nil)
 		_, err := client.DeleteTemplate(ctx, &api.DeleteTemplateRequest{Name: name})
 		assert.NoError(t, err)
 	})
@@ -175,8 +236,8 @@ func Test_DeleteTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
-		rMock.EXPECT().DeleteTemplate(pk).Return(fmt.Errorf("DeleteTemplate error"))
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().DeleteTemplate(mock.Anything, pk).Return(fmt.Errorf("DeleteTemplate error"))
 		_, err := client.DeleteTemplate(ctx, &api.DeleteTemplateRequest{Name: name})
 		assert.ErrorContains(t, err, "DeleteTemplate error")
 	})
@@ -184,7 +245,7 @@ func Test_DeleteTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(0, fmt.Errorf("GetTemplatePK error"))
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(0, fmt.Errorf("GetTemplatePK error"))
 		_, err := client.DeleteTemplate(ctx, &api.DeleteTemplateRequest{Name: name})
 		assert.ErrorContains(t, err, "GetTemplatePK error")
 	})
@@ -192,8 +253,8 @@ func Test_DeleteTemplate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
-		rMock.EXPECT().DeleteTemplate(pk).Return(nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().DeleteTemplate(mock.Anything, pk).Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/template/"+name, nil)
 		resp := httptest.NewRecorder()
@@ -205,12 +266,12 @@ func Test_DeleteTemplate(t *testing.T) {
 
 func Test_ListTemplates(t *testing.T) {
 	expNames := []string{"1", "1", "1"}
-	in := emptypb.Empty{}
+	in := api.ListTemplatesRequest{}
 	t.Run("Successful", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().ListTemplates().Return(expNames, nil)
+		rMock.EXPECT().ListTemplates(mock.Anything, false).Return(expNames, nil)
 		lt, err := client.ListTemplates(ctx, &in)
 		assert.NoError(t, err)
 		assert.ElementsMatch(t, expNames, lt.Names)
@@ -219,7 +280,7 @@ func Test_ListTemplates(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().ListTemplates().Return(nil, fmt.Errorf("Registry error"))
+		rMock.EXPECT().ListTemplates(mock.Anything, false).Return(nil, fmt.Errorf("Registry error"))
 		_, err := client.ListTemplates(ctx, &in)
 		assert.ErrorContains(t, err, "Registry error")
 	})
@@ -227,7 +288,7 @@ func Test_ListTemplates(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().ListTemplates().Return(expNames, nil)
+		rMock.EXPECT().ListTemplates(mock.Anything, false).Return(expNames, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/templates", nil)
 		resp := httptest.NewRecorder()
@@ -250,14 +311,14 @@ func Test_ListTemplates(t *testing.T) {
 
 func Test_DeleteCertificate(t *testing.T) {
 	id := "1"
-	cert := Certificate{}
+	cert := Certificate{Id: id}
 	t.Run("Successful", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(&cert, nil)
-		rMock.EXPECT().DeleteCertificate(id).Return(nil)
-		sMock.EXPECT().Delete(id, cert.Timestamp)
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&cert, nil)
+		rMock.EXPECT().DeleteCertificate(mock.Anything, id).Return(nil)
+		sMock.EXPECT().Delete(mock.Anything, cert.Timestamp).Times(len(variants()))
 		_, err := client.DeleteCertificate(ctx, &api.DeleteCertificateRequest{Id: id})
 		assert.NoError(t, err)
 	})
@@ -265,8 +326,8 @@ func Test_DeleteCertificate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(&cert, nil)
-		rMock.EXPECT().DeleteCertificate(id).Return(fmt.Errorf("DeleteCertificate error"))
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&cert, nil)
+		rMock.EXPECT().DeleteCertificate(mock.Anything, id).Return(fmt.Errorf("DeleteCertificate error"))
 		_, err := client.DeleteCertificate(ctx, &api.DeleteCertificateRequest{Id: id})
 		assert.ErrorContains(t, err, "DeleteCertificate error")
 	})
@@ -274,7 +335,7 @@ func Test_DeleteCertificate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(nil, fmt.Errorf("GetCertificate error"))
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(nil, fmt.Errorf("GetCertificate error"))
 		_, err := client.DeleteCertificate(ctx, &api.DeleteCertificateRequest{Id: id})
 		assert.ErrorContains(t, err, "GetCertificate error")
 	})
@@ -282,9 +343,9 @@ func Test_DeleteCertificate(t *testing.T) {
 		ctx := context.Background()
 		rMock, sMock, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(&cert, nil)
-		rMock.EXPECT().DeleteCertificate(id).Return(nil)
-		sMock.EXPECT().Delete(id, cert.Timestamp)
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&cert, nil)
+		rMock.EXPECT().DeleteCertificate(mock.Anything, id).Return(nil)
+		sMock.EXPECT().Delete(mock.Anything, cert.Timestamp).Times(len(variants()))
 
 		req := httptest.NewRequest(http.MethodDelete, "/certificate/"+id, nil)
 		resp := httptest.NewRecorder()
@@ -297,52 +358,80 @@ func Test_DeleteCertificate(t *testing.T) {
 func Test_UpdateTemplate(t *testing.T) {
 	pk := 1
 	name := "name"
-	m := make(map[string]string)
 	nName := "new name"
 	nContent := "new content"
-	m["name"] = nName
-	m["content"] = nContent
+	u := TemplateUpdate{Name: Set(nName), Content: Set(nContent)}
+	tmpl := &api.Template{Name: nName, Content: nContent}
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"name", "content"}}
 	t.Run("Successful", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
-		rMock.EXPECT().UpdateTemplate(pk, m).Return(nil)
-		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, NewName: &nName, NewContent: &nContent})
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().UpdateTemplate(mock.Anything, pk, u).Return(nil)
+		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, Template: tmpl, UpdateMask: mask})
+		assert.NoError(t, err)
+	})
+	t.Run("Only fields in mask are updated", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().UpdateTemplate(mock.Anything, pk, TemplateUpdate{Content: Set(nContent)}).Return(nil)
+		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, Template: tmpl,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}}})
+		assert.NoError(t, err)
+	})
+	t.Run("Populated fields are updated without mask", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().UpdateTemplate(mock.Anything, pk, TemplateUpdate{Name: Set(nName)}).Return(nil)
+		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, Template: &api.Template{Name: nName}})
 		assert.NoError(t, err)
 	})
 	t.Run("Registry returns error (UpdateTemplate failed)", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
-		rMock.EXPECT().UpdateTemplate(pk, m).Return(fmt.Errorf("UpdateTemplate error"))
-		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, NewName: &nName, NewContent: &nContent})
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().UpdateTemplate(mock.Anything, pk, u).Return(fmt.Errorf("UpdateTemplate error"))
+		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, Template: tmpl, UpdateMask: mask})
 		assert.ErrorContains(t, err, "UpdateTemplate error")
 	})
 	t.Run("Registry returns error (GetTemplatePK failed)", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(0, fmt.Errorf("GetTemplatePK error"))
-		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, NewName: &nName, NewContent: &nContent})
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(0, fmt.Errorf("GetTemplatePK error"))
+		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, Template: tmpl, UpdateMask: mask})
 		assert.ErrorContains(t, err, "GetTemplatePK error")
 	})
-	t.Run("Registry returns error (nothing to update)", func(t *testing.T) {
+	t.Run("Server returns error (nothing to update)", func(t *testing.T) {
 		ctx := context.Background()
-		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
 		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.ErrorContains(t, err, "no fields to update was provided")
 	})
+	t.Run("Server returns error (unknown field in mask)", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		_, err := client.UpdateTemplate(ctx, &api.UpdateTemplateRequest{Name: name, Template: tmpl,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"owner"}}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, `field "owner" can not be updated`)
+	})
 	t.Run("Update data through REST proxy", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetTemplatePK(name).Return(pk, nil)
-		rMock.EXPECT().UpdateTemplate(pk, m).Return(nil)
-		body := `{"NewName": ` + `"` + nName + `", "NewContent": ` + `"` + nContent + `"}`
+		rMock.EXPECT().GetTemplatePK(mock.Anything, name).Return(pk, nil)
+		rMock.EXPECT().UpdateTemplate(mock.Anything, pk, TemplateUpdate{Content: Set(nContent)}).Return(nil)
+		body := `{"content": ` + `"` + nContent + `"}`
 
 		req := httptest.NewRequest(http.MethodPatch, "/template/"+name, strings.NewReader(body))
 		resp := httptest.NewRecorder()
@@ -388,7 +477,7 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
 		sMock.EXPECT().Get(expCert.Id, expCert.Timestamp).Return(&expPdf, nil)
 
@@ -402,10 +491,10 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(false)
-		rMock.EXPECT().GetTemplateContent(expCert.TemplatePk).Return(&expTemplate, nil)
-		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink).Return(&expPdf, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(&expPdf, nil)
 		sMock.EXPECT().Add(expCert.Id, expCert.Timestamp, &expPdf).Return(nil)
 
 		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id})
@@ -413,13 +502,30 @@ func Test_GetCertificate(t *testing.T) {
 		assert.NotEmpty(t, got.GetData())
 	})
 
+	t.Run("Refuse to return revoked certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		revokedAt := time.Now()
+		revoked := expCert
+		revoked.RevokedAt = &revokedAt
+		revoked.RevocationReason = "misconduct"
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&revoked, nil)
+
+		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.ErrorContains(t, err, "revoked: misconduct")
+		assert.Nil(t, got)
+	})
+
 	t.Run("Registry GetCertificate returns error", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
 		expErr := "Registry GetCertificate error"
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(nil, fmt.Errorf(expErr))
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(nil, fmt.Errorf(expErr))
 
 		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id})
 		assert.ErrorContains(t, err, expErr)
@@ -431,7 +537,7 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
 		expErr := "Storage Get error"
 		sMock.EXPECT().Get(expCert.Id, expCert.Timestamp).Return(nil, fmt.Errorf(expErr))
@@ -446,10 +552,10 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(false)
 		expErr := "Registry GetTemplateContent error"
-		rMock.EXPECT().GetTemplateContent(expCert.TemplatePk).Return(nil, fmt.Errorf(expErr))
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(nil, fmt.Errorf(expErr))
 
 		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id})
 		assert.ErrorContains(t, err, expErr)
@@ -461,11 +567,11 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(false)
-		rMock.EXPECT().GetTemplateContent(expCert.TemplatePk).Return(&expTemplate, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(&expTemplate, nil)
 		expErr := "Templater GenerateCertificate error"
-		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink).Return(nil, fmt.Errorf(expErr))
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(nil, fmt.Errorf(expErr))
 
 		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id})
 		assert.ErrorContains(t, err, expErr)
@@ -478,10 +584,10 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(false)
-		rMock.EXPECT().GetTemplateContent(expCert.TemplatePk).Return(&expTemplate, nil)
-		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink).Return(&expPdf, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(&expPdf, nil)
 		expErr := "Storage Add error"
 		sMock.EXPECT().Add(expCert.Id, expCert.Timestamp, mock.Anything).Return(fmt.Errorf(expErr))
 
@@ -495,10 +601,10 @@ func Test_GetCertificate(t *testing.T) {
 		rMock, sMock, tMock, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetCertificate(expCert.Id).Return(&expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
 		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(false)
-		rMock.EXPECT().GetTemplateContent(expCert.TemplatePk).Return(&expTemplate, nil)
-		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink).Return(&expPdf, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(&expPdf, nil)
 		sMock.EXPECT().Add(expCert.Id, expCert.Timestamp, mock.Anything).Return(nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id, nil)
@@ -513,6 +619,244 @@ func Test_GetCertificate(t *testing.T) {
 		}
 		assert.NotEmpty(t, pdf)
 	})
+
+	t.Run("Redirect to signed URL of stored certificate through REST", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, uMock, server, mux := initTestRedirectingServer(t, ctx)
+		expURL := "https://bucket.example.com/cert.pdf?X-Amz-Signature=abc"
+
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
+		uMock.EXPECT().SignedURL(expCert.Id, expCert.Timestamp, time.Minute).Return(expURL, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id, nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusFound, resp.Code)
+		assert.Equal(t, expURL, resp.Header().Get("Location"))
+		assert.Empty(t, resp.Body.Bytes())
+
+		// gRPC clients aren't redirected
+		sMock.EXPECT().Get(expCert.Id, expCert.Timestamp).Return(&expPdf, nil)
+		got, err := server.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, expPdf, got.GetData())
+	})
+
+	t.Run("Serve certificate through REST when signing fails", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, uMock, _, mux := initTestRedirectingServer(t, ctx)
+
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
+		uMock.EXPECT().SignedURL(expCert.Id, expCert.Timestamp, time.Minute).Return("", fmt.Errorf("sign error"))
+		sMock.EXPECT().Get(expCert.Id, expCert.Timestamp).Return(&expPdf, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id, nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, expPdf, resp.Body.Bytes())
+	})
+	t.Run("Generate thumbnail and return it", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		v := Variant{Format: FormatPNG, Width: 320}
+		expPng := []byte{1, 0, 1, 0}
+
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id+".png-320", expCert.Timestamp).Return(false)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, v).Return(&expPng, nil)
+		sMock.EXPECT().Add(expCert.Id+".png-320", expCert.Timestamp, &expPng).Return(nil)
+
+		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id, Format: "png", Width: 320})
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", got.GetContentType())
+		assert.Equal(t, expPng, got.GetData())
+	})
+
+	t.Run("Refuse unknown variant", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		got, err := client.GetCertificate(ctx, &api.GetCertificateRequest{Id: expCert.Id, Format: "png", Width: 100})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, got)
+	})
+
+	t.Run("Serve stored image through REST without redirect", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, _, mux := initTestRedirectingServer(t, ctx)
+		expJpeg := []byte{1, 1, 0, 0}
+
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id+".jpeg-1200", expCert.Timestamp).Return(true)
+		sMock.EXPECT().Get(expCert.Id+".jpeg-1200", expCert.Timestamp).Return(&expJpeg, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id+"?format=jpeg&width=1200", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "image/jpeg", resp.Header().Get("Content-Type"))
+		assert.Equal(t, expJpeg, resp.Body.Bytes())
+	})
+}
+
+func Test_DownloadCertificate(t *testing.T) {
+	expCert := Certificate{Id: "12345678", TemplatePk: 1, Timestamp: time.Now()}
+	expPdf := make([]byte, downloadChunkSize+10)
+	for i := range expPdf {
+		expPdf[i] = byte(i)
+	}
+	expTemplate := "Test Template"
+	expLink := host + "certificate/" + expCert.Id
+	download := func(t *testing.T, ctx context.Context, client api.CertsServiceClient) ([]string, []byte, error) {
+		stream, err := client.DownloadCertificate(ctx, &api.DownloadCertificateRequest{Id: expCert.Id})
+		if err != nil {
+			return nil, nil, err
+		}
+		var contentTypes []string
+		var data []byte
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return contentTypes, data, nil
+			}
+			if err != nil {
+				return contentTypes, data, err
+			}
+			contentTypes = append(contentTypes, chunk.GetContentType())
+			data = append(data, chunk.GetData()...)
+		}
+	}
+
+	t.Run("Stream certificate from storage in chunks", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
+		sMock.EXPECT().Open(expCert.Id, expCert.Timestamp).Return(io.NopCloser(bytes.NewReader(expPdf)), nil)
+
+		contentTypes, data, err := download(t, ctx, client)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"application/pdf", ""}, contentTypes)
+		assert.Equal(t, expPdf, data)
+	})
+
+	t.Run("Generate new certificate and stream it", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		empty := []byte{}
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(false)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expCert.TemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(&empty, nil)
+		sMock.EXPECT().Add(expCert.Id, expCert.Timestamp, &empty).Return(nil)
+
+		// Empty file still carries content type
+		contentTypes, data, err := download(t, ctx, client)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"application/pdf"}, contentTypes)
+		assert.Empty(t, data)
+	})
+
+	t.Run("Refuse to stream revoked certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		revokedAt := time.Now()
+		revoked := expCert
+		revoked.RevokedAt = &revokedAt
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&revoked, nil)
+
+		_, _, err := download(t, ctx, client)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Storage Open returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
+		expErr := "Storage Open error"
+		sMock.EXPECT().Open(expCert.Id, expCert.Timestamp).Return(nil, fmt.Errorf(expErr))
+
+		_, _, err := download(t, ctx, client)
+		assert.ErrorContains(t, err, expErr)
+	})
+
+	t.Run("Serve range of certificate through REST", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		sum := checksum(expPdf)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
+		sMock.EXPECT().Open(expCert.Id, expCert.Timestamp).Return(bytesFile{bytes.NewReader(expPdf)}, nil)
+		sMock.EXPECT().Checksum(expCert.Id, expCert.Timestamp).Return(sum, true)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id+"/download", nil)
+		req.Header.Set("Range", "bytes=10-19")
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusPartialContent, resp.Code)
+		assert.Equal(t, "application/pdf", resp.Header().Get("Content-Type"))
+		assert.Equal(t, `"`+sum+`"`, resp.Header().Get("ETag"))
+		assert.Equal(t, expPdf[10:20], resp.Body.Bytes())
+	})
+
+	t.Run("Not modified certificate through REST", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		sum := checksum(expPdf)
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&expCert, nil)
+		sMock.EXPECT().Contains(expCert.Id, expCert.Timestamp).Return(true)
+		sMock.EXPECT().Open(expCert.Id, expCert.Timestamp).Return(bytesFile{bytes.NewReader(expPdf)}, nil)
+		sMock.EXPECT().Checksum(expCert.Id, expCert.Timestamp).Return(sum, true)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id+"/download", nil)
+		req.Header.Set("If-None-Match", `"`+sum+`"`)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+		assert.Empty(t, resp.Body.Bytes())
+	})
+
+	t.Run("Revoked certificate through REST", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+
+		revokedAt := time.Now()
+		revoked := expCert
+		revoked.RevokedAt = &revokedAt
+		rMock.EXPECT().GetCertificate(mock.Anything, expCert.Id).Return(&revoked, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id+"/download", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "was revoked")
+	})
 }
 
 func Test_TestTemplate(t *testing.T) {
@@ -542,9 +886,9 @@ func Test_TestTemplate(t *testing.T) {
 		rMock, _, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetTemplatePK(expTemplateName).Return(expTemplatePk, nil)
-		rMock.EXPECT().GetTemplateContent(expTemplatePk).Return(&expTemplate, nil)
-		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink).Return(&expPdf, nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, expTemplateName).Return(expTemplatePk, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expTemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(&expPdf, nil)
 
 		got, err := client.TestTemplate(ctx, &api.TestTemplateRequest{Name: expTemplateName, Certificate: &expCertRequest})
 
@@ -552,13 +896,30 @@ func Test_TestTemplate(t *testing.T) {
 		assert.Equal(t, expPdf, got.GetData())
 	})
 
+	t.Run("Generate test thumbnail", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		expWebp := []byte{1, 0, 1, 0}
+
+		rMock.EXPECT().GetTemplatePK(mock.Anything, expTemplateName).Return(expTemplatePk, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expTemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, Variant{Format: FormatWEBP, Width: 640}).Return(&expWebp, nil)
+
+		got, err := client.TestTemplate(ctx, &api.TestTemplateRequest{Name: expTemplateName, Certificate: &expCertRequest, Format: "webp", Width: 640})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image/webp", got.GetContentType())
+		assert.Equal(t, expWebp, got.GetData())
+	})
+
 	t.Run("Registry GetTemplatePK returns error", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
 		expErr := "Registry GetTemplatePK error"
-		rMock.EXPECT().GetTemplatePK(expTemplateName).Return(0, fmt.Errorf(expErr))
+		rMock.EXPECT().GetTemplatePK(mock.Anything, expTemplateName).Return(0, fmt.Errorf(expErr))
 
 		got, err := client.TestTemplate(ctx, &api.TestTemplateRequest{Name: expTemplateName, Certificate: &expCertRequest})
 
@@ -571,9 +932,9 @@ func Test_TestTemplate(t *testing.T) {
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetTemplatePK(expTemplateName).Return(expTemplatePk, nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, expTemplateName).Return(expTemplatePk, nil)
 		expErr := "Registry GetTemplateContent error"
-		rMock.EXPECT().GetTemplateContent(expTemplatePk).Return(nil, fmt.Errorf(expErr))
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expTemplatePk).Return(nil, fmt.Errorf(expErr))
 
 		got, err := client.TestTemplate(ctx, &api.TestTemplateRequest{Name: expTemplateName, Certificate: &expCertRequest})
 
@@ -586,10 +947,10 @@ func Test_TestTemplate(t *testing.T) {
 		rMock, _, tMock, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetTemplatePK(expTemplateName).Return(expTemplatePk, nil)
-		rMock.EXPECT().GetTemplateContent(expTemplatePk).Return(&expTemplate, nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, expTemplateName).Return(expTemplatePk, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expTemplatePk).Return(&expTemplate, nil)
 		expErr := "Templater GenerateCertificate error"
-		tMock.EXPECT().GenerateCertificate(expTemplate, mock.Anything, expLink).Return(nil, fmt.Errorf(expErr))
+		tMock.EXPECT().GenerateCertificate(expTemplate, mock.Anything, expLink, PDF).Return(nil, fmt.Errorf(expErr))
 
 		got, err := client.TestTemplate(ctx, &api.TestTemplateRequest{Name: expTemplateName, Certificate: &expCertRequest})
 
@@ -603,9 +964,9 @@ func Test_TestTemplate(t *testing.T) {
 		rMock, _, tMock, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
 
-		rMock.EXPECT().GetTemplatePK(expTmplName).Return(expTemplatePk, nil)
-		rMock.EXPECT().GetTemplateContent(expTemplatePk).Return(&expTemplate, nil)
-		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink).Return(&expPdf, nil)
+		rMock.EXPECT().GetTemplatePK(mock.Anything, expTmplName).Return(expTemplatePk, nil)
+		rMock.EXPECT().GetTemplateContent(mock.Anything, expTemplatePk).Return(&expTemplate, nil)
+		tMock.EXPECT().GenerateCertificate(expTemplate, &expCert, expLink, PDF).Return(&expPdf, nil)
 
 		body := `{"certificate": {"id": ` + `"` + expCertRequest.Id + `", "student": ` + `"` + expCertRequest.Student + `"` +
 			`, "issueDate": ` + `"` + expCertRequest.IssueDate + `", "course": ` + `"` + expCertRequest.Course + `"` +
@@ -626,6 +987,65 @@ func Test_TestTemplate(t *testing.T) {
 	})
 }
 
+func Test_GetCertificateMetadata(t *testing.T) {
+	revokedAt := time.Now().UTC()
+	cert := &Certificate{Id: "12345678", TemplatePk: 1, Timestamp: time.Now().UTC(), Student: "O'Brien",
+		IssueDate: "1 December 1999", Course: "Test Course", Mentors: "Test Mentor", RevokedAt: &revokedAt, RevocationReason: "misconduct"}
+	tmpl := &Template{Pk: 1, Name: "template"}
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(cert, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil)
+		got, err := client.GetCertificateMetadata(ctx, &api.GetCertificateMetadataRequest{Id: cert.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, cert.Id, got.GetId())
+		assert.Equal(t, "template", got.GetTemplate())
+		assert.Equal(t, cert.Student, got.GetStudent())
+		assert.Equal(t, cert.IssueDate, got.GetIssueDate())
+		assert.Equal(t, cert.Course, got.GetCourse())
+		assert.Equal(t, cert.Mentors, got.GetMentors())
+		assert.Equal(t, cert.Timestamp, got.GetTimestamp().AsTime())
+		assert.Equal(t, revokedAt, got.GetRevokedAt().AsTime())
+		assert.Equal(t, "misconduct", got.GetRevocationReason())
+		assert.Equal(t, host+"certificate/"+cert.Id, got.GetLink())
+		assert.Nil(t, got.GetValidFrom())
+	})
+	t.Run("Registry returns error (GetCertificate failed)", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(nil, fmt.Errorf("GetCertificate error"))
+		_, err := client.GetCertificateMetadata(ctx, &api.GetCertificateMetadataRequest{Id: cert.Id})
+		assert.ErrorContains(t, err, "GetCertificate error")
+	})
+	t.Run("Registry returns error (GetTemplate failed)", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(cert, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(nil, fmt.Errorf("GetTemplate error"))
+		_, err := client.GetCertificateMetadata(ctx, &api.GetCertificateMetadataRequest{Id: cert.Id})
+		assert.ErrorContains(t, err, "GetTemplate error")
+	})
+	t.Run("Get data through REST proxy", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(cert, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+cert.Id+"/metadata", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), `"student":"O'Brien"`)
+		assert.Contains(t, resp.Body.String(), `"template":"template"`)
+	})
+}
+
 func Test_UpdateCertificate(t *testing.T) {
 	id := "123456789"
 	template := "nTemplate"
@@ -633,43 +1053,77 @@ func Test_UpdateCertificate(t *testing.T) {
 	issue_date := "nIssueDate"
 	course := "nCourse"
 	mentors := "nMentors"
-	m := make(map[string]string)
-	m["template"] = template
-	m["student"] = student
-	m["issue_date"] = issue_date
-	m["course"] = course
-	m["mentors"] = mentors
+	u := CertificateUpdate{
+		Template:  Set(template),
+		Student:   Set(student),
+		IssueDate: Set(issue_date),
+		Course:    Set(course),
+		Mentors:   Set(mentors),
+	}
+	cert := &api.Certificate{Template: template, Student: student, IssueDate: issue_date, Course: course, Mentors: mentors}
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"template", "student", "issueDate", "course", "mentors"}}
 	t.Run("Successful", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().UpdateCertificate(id, m).Return(nil)
-		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, NewTemplate: &template, NewStudent: &student, NewIssueDate: &issue_date, NewCourse: &course, NewMentors: &mentors})
+		rMock.EXPECT().UpdateCertificate(mock.Anything, id, u).Return(time.Now(), nil)
+		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, Certificate: cert, UpdateMask: mask})
+		assert.NoError(t, err)
+	})
+	t.Run("Wildcard mask updates all fields, clearing empty ones", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		all := u
+		all.ValidFrom = Set[*time.Time](nil)
+		all.ValidUntil = Set[*time.Time](nil)
+		rMock.EXPECT().UpdateCertificate(mock.Anything, id, all).Return(time.Now(), nil)
+		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, Certificate: cert,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}}})
+		assert.NoError(t, err)
+	})
+	t.Run("Populated fields are updated without mask, output only ones are ignored", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		validUntil := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		rMock.EXPECT().UpdateCertificate(mock.Anything, id, CertificateUpdate{Student: Set(student), ValidUntil: Set(&validUntil)}).
+			Return(time.Now(), nil)
+		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, Certificate: &api.Certificate{
+			Id: id, Link: "http://example.com/", Student: student, ValidUntil: timestamppb.New(validUntil)}})
 		assert.NoError(t, err)
 	})
 	t.Run("Registry returns error (UpdateCertificate failed)", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().UpdateCertificate(id, m).Return(fmt.Errorf("UpdateCertificate error"))
-		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, NewTemplate: &template, NewStudent: &student, NewIssueDate: &issue_date, NewCourse: &course, NewMentors: &mentors})
+		rMock.EXPECT().UpdateCertificate(mock.Anything, id, u).Return(time.Time{}, fmt.Errorf("UpdateCertificate error"))
+		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, Certificate: cert, UpdateMask: mask})
 		assert.ErrorContains(t, err, "UpdateCertificate error")
 	})
-	t.Run("Registry returns error (nothing to update)", func(t *testing.T) {
+	t.Run("Server returns error (nothing to update)", func(t *testing.T) {
 		ctx := context.Background()
 		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
 		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.ErrorContains(t, err, "no fields to update was provided")
 	})
+	t.Run("Server returns error (unknown field in mask)", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		_, err := client.UpdateCertificate(ctx, &api.UpdateCertificateRequest{Id: id, Certificate: cert,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"validUntil.seconds"}}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 	t.Run("Update data through REST proxy", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().UpdateCertificate(id, m).Return(nil)
-		body := `{"NewTemplate": ` + `"` + template + `", "NewStudent": ` + `"` + student +
-			`", "NewIssueDate": ` + `"` + issue_date + `", "NewCourse": ` + `"` + course +
-			`", "NewMentors": ` + `"` + mentors + `"}`
+		rMock.EXPECT().UpdateCertificate(mock.Anything, id, CertificateUpdate{Student: Set("O'Brien"), ValidUntil: Set[*time.Time](nil)}).
+			Return(time.Now(), nil)
+		body := `{"student": "O'Brien", "validUntil": null}`
 
 		req := httptest.NewRequest(http.MethodPatch, "/certificate/"+id, strings.NewReader(body))
 		resp := httptest.NewRecorder()
@@ -690,16 +1144,29 @@ func Test_AddCertificate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().AddCertificate(templateName, student, issueDate, course, mentors).Return(expCert, nil)
+		rMock.EXPECT().AddCertificate(mock.Anything, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil)).Return(expCert, nil)
 		got, err := client.AddCertificate(ctx, &api.AddCertificateRequest{TemplateName: templateName, Student: student, IssueDate: issueDate, Course: course, Mentors: mentors})
 		assert.Equal(t, got.Id, expCert.Id)
 		assert.NoError(t, err)
 	})
+	t.Run("Successfull adding with validity window", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		validFrom := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		validUntil := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		rMock.EXPECT().AddCertificate(mock.Anything, templateName, student, issueDate, course, mentors, &validFrom, &validUntil).
+			Return(expCert, nil)
+		got, err := client.AddCertificate(ctx, &api.AddCertificateRequest{TemplateName: templateName, Student: student, IssueDate: issueDate, Course: course, Mentors: mentors,
+			ValidFrom: timestamppb.New(validFrom), ValidUntil: timestamppb.New(validUntil)})
+		assert.Equal(t, got.Id, expCert.Id)
+		assert.NoError(t, err)
+	})
 	t.Run("Failed adding. Error returns", func(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().AddCertificate(templateName, student, issueDate, course, mentors).Return(nil, fmt.Errorf("AddCertificate error"))
+		rMock.EXPECT().AddCertificate(mock.Anything, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil)).Return(nil, fmt.Errorf("AddCertificate error"))
 		got, err := client.AddCertificate(ctx, &api.AddCertificateRequest{TemplateName: templateName, Student: student, IssueDate: issueDate, Course: course, Mentors: mentors})
 		assert.Nil(t, got)
 		assert.ErrorContains(t, err, "AddCertificate error")
@@ -708,7 +1175,7 @@ func Test_AddCertificate(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().AddCertificate(templateName, student, issueDate, course, mentors).Return(expCert, nil)
+		rMock.EXPECT().AddCertificate(mock.Anything, templateName, student, issueDate, course, mentors, (*time.Time)(nil), (*time.Time)(nil)).Return(expCert, nil)
 
 		body := `{"templateName": ` + `"` + templateName + `", "student": ` + `"` + student + `"` +
 			`, "issueDate": ` + `"` + issueDate + `", "course": ` + `"` + course + `"` +
@@ -747,7 +1214,7 @@ func Test_GetCertificateLink(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(expCert, nil)
 		got, err := client.GetCertificateLink(ctx, &api.GetCertificateLinkRequest{Id: id})
 		assert.Equal(t, got.Link, expLink)
 		assert.NoError(t, err)
@@ -756,7 +1223,7 @@ func Test_GetCertificateLink(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(nil, fmt.Errorf("GetCertificate error"))
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(nil, fmt.Errorf("GetCertificate error"))
 		got, err := client.GetCertificateLink(ctx, &api.GetCertificateLinkRequest{Id: id})
 		assert.Nil(t, got)
 		assert.ErrorContains(t, err, "GetCertificate error")
@@ -765,7 +1232,7 @@ func Test_GetCertificateLink(t *testing.T) {
 		ctx := context.Background()
 		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
 		defer closer()
-		rMock.EXPECT().GetCertificate(id).Return(expCert, nil)
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(expCert, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/certificate/"+expCert.Id+"/link", nil)
 		resp := httptest.NewRecorder()
@@ -788,3 +1255,466 @@ func Test_GetCertificateLink(t *testing.T) {
 		assert.Equal(t, expLink, m["link"])
 	})
 }
+
+func Test_ListAuditEvents(t *testing.T) {
+	id := "12345678"
+	now := time.Now().UTC()
+	expEvents := []AuditEvent{
+		{Id: 1, Time: now, Actor: "CN=admin", OnBehalfOf: "teacher", Action: AuditCreate, Entity: AuditCertificate, EntityId: id,
+			After: json.RawMessage(`{"student": "A"}`)},
+	}
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListAuditEvents(mock.Anything, AuditFilter{EntityId: id, From: now}).Return(expEvents, nil)
+		got, err := client.ListAuditEvents(ctx, &api.ListAuditEventsRequest{EntityId: id, From: timestamppb.New(now)})
+		assert.NoError(t, err)
+		assert.Len(t, got.GetEvents(), 1)
+		assert.Equal(t, "CN=admin", got.GetEvents()[0].GetActor())
+		assert.Equal(t, "teacher", got.GetEvents()[0].GetOnBehalfOf())
+		assert.Equal(t, now, got.GetEvents()[0].GetTime().AsTime())
+		assert.Equal(t, `{"student": "A"}`, got.GetEvents()[0].GetAfter())
+		assert.Empty(t, got.GetEvents()[0].GetBefore())
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListAuditEvents(mock.Anything, AuditFilter{}).Return(nil, fmt.Errorf("ListAuditEvents error"))
+		_, err := client.ListAuditEvents(ctx, &api.ListAuditEventsRequest{})
+		assert.ErrorContains(t, err, "ListAuditEvents error")
+	})
+	t.Run("Unknown entity", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		_, err := client.ListAuditEvents(ctx, &api.ListAuditEventsRequest{Entity: "student", EntityId: id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Get data through REST proxy", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListAuditEvents(mock.Anything, AuditFilter{Entity: AuditCertificate, EntityId: id}).Return(expEvents, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/audit?entity=certificate&entityId="+id, nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), `"actor":"CN=admin"`)
+		assert.Contains(t, resp.Body.String(), `"onBehalfOf":"teacher"`)
+	})
+}
+
+func Test_ErrorInterceptors(t *testing.T) {
+	t.Run("Unknown certificate through gRPC", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, "12345678").Return(nil, fmt.Errorf("wrapped: %w", ErrNotFound))
+		_, err := client.GetCertificateLink(ctx, &api.GetCertificateLinkRequest{Id: "12345678"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("Invalid id through REST", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, "x").Return(nil, fmt.Errorf("%w: %q", ErrInvalidId, "x"))
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/x/link", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+	t.Run("Unknown certificate through stream", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, "12345678").Return(nil, ErrNotFound)
+		stream, err := client.DownloadCertificate(ctx, &api.DownloadCertificateRequest{Id: "12345678"})
+		if assert.NoError(t, err) {
+			_, err = stream.Recv()
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("Unknown certificate through REST handler", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, "12345678").Return(nil, ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/12345678/download", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func Test_peerAddress(t *testing.T) {
+	tData := map[string]struct {
+		addr net.Addr
+		md   metadata.MD
+		exp  string
+	}{
+		"TCP peer": {&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}, nil, "10.0.0.1:5000"},
+		"TCP peer ignores forwarded for": {&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000},
+			metadata.Pairs(forwardedForMetadataKey, "10.0.0.2"), "10.0.0.1:5000"},
+		"Gateway peer uses address appended by gateway": {testAddr{gatewayNetwork, "bufconn"},
+			metadata.Pairs(forwardedForMetadataKey, "1.2.3.4, 10.0.0.3", forwardedForMetadataKey, "10.0.0.2"), "10.0.0.2"},
+		"Gateway peer without forwarded for": {testAddr{gatewayNetwork, "bufconn"}, nil, "bufconn"},
+	}
+	for name, d := range tData {
+		d := d
+		t.Run(name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: d.addr})
+			if d.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, d.md)
+			}
+			assert.Equal(t, d.exp, peerAddress(ctx))
+		})
+	}
+	t.Run("No peer", func(t *testing.T) {
+		assert.Equal(t, "", peerAddress(context.Background()))
+	})
+}
+
+func Test_ActorUnaryInterceptor(t *testing.T) {
+	name := "Name"
+	content := "Test content"
+	actedBy := func(actor, onBehalfOf string) any {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			return ActorFromContext(ctx) == actor && OnBehalfOfFromContext(ctx) == onBehalfOf
+		})
+	}
+	t.Run("Actor passed through gRPC metadata is recorded as on behalf of", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().AddTemplate(actedBy("bufconn", "admin"), name, content).Return(nil)
+		ctx = metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, "admin")
+		_, err := client.AddTemplate(ctx, &api.AddTemplateRequest{Name: name, Content: content})
+		assert.NoError(t, err)
+	})
+	t.Run("Actor passed through REST header is recorded as on behalf of client address", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().AddTemplate(actedBy("192.0.2.1", "admin"), name, content).Return(nil)
+		body := `{"name": ` + `"` + name + `", "content": ` + `"` + content + `"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/template", strings.NewReader(body))
+		req.Header.Set("X-Actor", "admin")
+		// Addresses sent by client precede the one appended by gateway
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	})
+	t.Run("Peer address used without actor", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().AddTemplate(actedBy("bufconn", ""), name, content).Return(nil)
+		_, err := client.AddTemplate(ctx, &api.AddTemplateRequest{Name: name, Content: content})
+		assert.NoError(t, err)
+	})
+}
+
+func Test_RevokeCertificate(t *testing.T) {
+	id := "12345678"
+	reason := "misconduct"
+	cert := Certificate{Id: id, Timestamp: time.Now()}
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&cert, nil)
+		rMock.EXPECT().RevokeCertificate(mock.Anything, id, reason).Return(nil)
+		sMock.EXPECT().Delete(mock.Anything, cert.Timestamp).Times(len(variants()))
+		_, err := client.RevokeCertificate(ctx, &api.RevokeCertificateRequest{Id: id, Reason: reason})
+		assert.NoError(t, err)
+	})
+	t.Run("Registry returns error (RevokeCertificate failed)", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&cert, nil)
+		rMock.EXPECT().RevokeCertificate(mock.Anything, id, reason).Return(fmt.Errorf("RevokeCertificate error"))
+		_, err := client.RevokeCertificate(ctx, &api.RevokeCertificateRequest{Id: id, Reason: reason})
+		assert.ErrorContains(t, err, "RevokeCertificate error")
+	})
+	t.Run("Registry returns error (GetCertificate failed)", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(nil, fmt.Errorf("GetCertificate error"))
+		_, err := client.RevokeCertificate(ctx, &api.RevokeCertificateRequest{Id: id, Reason: reason})
+		assert.ErrorContains(t, err, "GetCertificate error")
+	})
+	t.Run("Revoke through REST proxy", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&cert, nil)
+		rMock.EXPECT().RevokeCertificate(mock.Anything, id, reason).Return(nil)
+		sMock.EXPECT().Delete(mock.Anything, cert.Timestamp).Times(len(variants()))
+
+		req := httptest.NewRequest(http.MethodPost, "/certificate/"+id+"/revoke", strings.NewReader(`{"reason": "`+reason+`"}`))
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	})
+}
+
+func Test_UnrevokeCertificate(t *testing.T) {
+	id := "12345678"
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().UnrevokeCertificate(mock.Anything, id).Return(nil)
+		_, err := client.UnrevokeCertificate(ctx, &api.UnrevokeCertificateRequest{Id: id})
+		assert.NoError(t, err)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().UnrevokeCertificate(mock.Anything, id).Return(fmt.Errorf("UnrevokeCertificate error"))
+		_, err := client.UnrevokeCertificate(ctx, &api.UnrevokeCertificateRequest{Id: id})
+		assert.ErrorContains(t, err, "UnrevokeCertificate error")
+	})
+}
+
+func Test_VerifyCertificate(t *testing.T) {
+	id := "12345678"
+	revokedAt := time.Now().UTC()
+	t.Run("Valid certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&Certificate{Id: id}, nil)
+		sMock.EXPECT().Checksum(id, mock.Anything).Return("9f86d081", true)
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_VALID, got.GetStatus())
+		assert.Nil(t, got.GetRevokedAt())
+		assert.Equal(t, "9f86d081", got.GetChecksum())
+	})
+	t.Run("Revoked certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).
+			Return(&Certificate{Id: id, RevokedAt: &revokedAt, RevocationReason: "misconduct"}, nil)
+		sMock.EXPECT().Checksum(id, mock.Anything).Return("", false)
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_REVOKED, got.GetStatus())
+		assert.Equal(t, revokedAt, got.GetRevokedAt().AsTime())
+		assert.Equal(t, "misconduct", got.GetRevocationReason())
+	})
+	t.Run("Expired certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		validUntil := time.Now().Add(-time.Hour).UTC()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&Certificate{Id: id, ValidUntil: &validUntil}, nil)
+		sMock.EXPECT().Checksum(id, mock.Anything).Return("", false)
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_EXPIRED, got.GetStatus())
+		assert.Equal(t, validUntil, got.GetValidUntil().AsTime())
+		assert.Nil(t, got.GetValidFrom())
+	})
+	t.Run("Not yet valid certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		validFrom := time.Now().Add(time.Hour).UTC()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(&Certificate{Id: id, ValidFrom: &validFrom}, nil)
+		sMock.EXPECT().Checksum(id, mock.Anything).Return("", false)
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_NOT_YET_VALID, got.GetStatus())
+		assert.Equal(t, validFrom, got.GetValidFrom().AsTime())
+	})
+	t.Run("Revoked certificate takes precedence over expired", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		validUntil := time.Now().Add(-time.Hour)
+		rMock.EXPECT().GetCertificate(mock.Anything, id).
+			Return(&Certificate{Id: id, RevokedAt: &revokedAt, ValidUntil: &validUntil}, nil)
+		sMock.EXPECT().Checksum(id, mock.Anything).Return("", false)
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_REVOKED, got.GetStatus())
+	})
+	t.Run("Unknown certificate", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(nil, fmt.Errorf("wrapped: %w", ErrNotFound))
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_NOT_FOUND, got.GetStatus())
+	})
+	t.Run("Malformed certificate id", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, "x").Return(nil, fmt.Errorf("%w: %q", ErrInvalidId, "x"))
+		got, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: "x"})
+		assert.NoError(t, err)
+		assert.Equal(t, api.VerifyCertificateResponse_NOT_FOUND, got.GetStatus())
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).Return(nil, fmt.Errorf("GetCertificate error"))
+		_, err := client.VerifyCertificate(ctx, &api.VerifyCertificateRequest{Id: id})
+		assert.ErrorContains(t, err, "GetCertificate error")
+	})
+	t.Run("Verify through REST proxy", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, sMock, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().GetCertificate(mock.Anything, id).
+			Return(&Certificate{Id: id, RevokedAt: &revokedAt, RevocationReason: "misconduct"}, nil)
+		sMock.EXPECT().Checksum(id, mock.Anything).Return("", false)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificate/"+id+"/verify", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), `"status":"REVOKED"`)
+	})
+}
+
+func Test_RestoreTemplate(t *testing.T) {
+	name := "name"
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().RestoreTemplate(mock.Anything, name).Return(nil)
+		_, err := client.RestoreTemplate(ctx, &api.RestoreTemplateRequest{Name: name})
+		assert.NoError(t, err)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().RestoreTemplate(mock.Anything, name).Return(fmt.Errorf("RestoreTemplate error"))
+		_, err := client.RestoreTemplate(ctx, &api.RestoreTemplateRequest{Name: name})
+		assert.ErrorContains(t, err, "RestoreTemplate error")
+	})
+	t.Run("Restore through REST proxy", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().RestoreTemplate(mock.Anything, name).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/template/"+name+"/restore", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	})
+}
+
+func Test_RestoreCertificate(t *testing.T) {
+	id := "12345678"
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().RestoreCertificate(mock.Anything, id).Return(nil)
+		_, err := client.RestoreCertificate(ctx, &api.RestoreCertificateRequest{Id: id})
+		assert.NoError(t, err)
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().RestoreCertificate(mock.Anything, id).Return(fmt.Errorf("RestoreCertificate error"))
+		_, err := client.RestoreCertificate(ctx, &api.RestoreCertificateRequest{Id: id})
+		assert.ErrorContains(t, err, "RestoreCertificate error")
+	})
+}
+
+func Test_ListExpiringCertificates(t *testing.T) {
+	within := 30 * 24 * time.Hour
+	validUntil := time.Now().Add(time.Hour).UTC()
+	certs := []*Certificate{
+		{Id: "12345678", TemplatePk: 1, Student: "student", ValidUntil: &validUntil},
+		{Id: "87654321", TemplatePk: 1, Student: "other student", ValidUntil: &validUntil},
+	}
+	tmpl := &Template{Pk: 1, Name: "template"}
+	t.Run("Successful", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListExpiringCertificates(mock.Anything, within).Return(certs, nil)
+		// Template name is looked up once for certificates sharing it
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil).Once()
+		got, err := client.ListExpiringCertificates(ctx, &api.ListExpiringCertificatesRequest{Within: durationpb.New(within)})
+		assert.NoError(t, err)
+		if assert.Len(t, got.GetCertificates(), 2) {
+			c := got.GetCertificates()[0]
+			assert.Equal(t, "12345678", c.GetId())
+			assert.Equal(t, "student", c.GetStudent())
+			assert.Equal(t, validUntil, c.GetValidUntil().AsTime())
+			assert.Nil(t, c.GetValidFrom())
+			assert.Equal(t, host+"certificate/12345678", c.GetLink())
+			assert.Equal(t, "template", c.GetTemplate())
+		}
+	})
+	t.Run("Registry returns error (GetTemplate failed)", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListExpiringCertificates(mock.Anything, within).Return(certs, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(nil, fmt.Errorf("GetTemplate error"))
+		_, err := client.ListExpiringCertificates(ctx, &api.ListExpiringCertificatesRequest{Within: durationpb.New(within)})
+		assert.ErrorContains(t, err, "GetTemplate error")
+	})
+	t.Run("Missing duration", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		_, err := client.ListExpiringCertificates(ctx, &api.ListExpiringCertificatesRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Registry returns error", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, client, closer, _ := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListExpiringCertificates(mock.Anything, within).Return(nil, fmt.Errorf("ListExpiringCertificates error"))
+		_, err := client.ListExpiringCertificates(ctx, &api.ListExpiringCertificatesRequest{Within: durationpb.New(within)})
+		assert.ErrorContains(t, err, "ListExpiringCertificates error")
+	})
+	t.Run("List through REST proxy", func(t *testing.T) {
+		ctx := context.Background()
+		rMock, _, _, _, closer, mux := initTestServerAndConn(t, ctx)
+		defer closer()
+		rMock.EXPECT().ListExpiringCertificates(mock.Anything, within).Return(certs, nil)
+		rMock.EXPECT().GetTemplate(mock.Anything, 1).Return(tmpl, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/certificates/expiring?within=2592000s", nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), `"id":"12345678"`)
+	})
+}
@@ -0,0 +1,149 @@
+package golangunitedschoolcerts
+
+import (
+	"bytes"
+	tmpl "html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// Organization issuing certificates, shown on share pages and passed to LinkedIn
+type Issuer struct {
+	Name string `yaml:"name"`
+	// Website of organization, optional
+	URL string `yaml:"url"`
+	// Id of organization page on LinkedIn, optional. Without it LinkedIn matches organization by name
+	LinkedInID string `yaml:"linkedInId"`
+}
+
+// Open Graph image, JPEG is understood by every social network
+var shareImage = Variant{Format: FormatJPEG, Width: 1200}
+
+// Image shown on share page itself
+var sharePreview = Variant{Format: FormatWEBP, Width: 640}
+
+// Data structure for share page template
+type sharePage struct {
+	Cert     Certificate
+	Issuer   Issuer
+	Title    string
+	URL      string
+	Image    string
+	Preview  string
+	PDF      string
+	LinkedIn string
+}
+
+var sharePageTemplate = tmpl.Must(tmpl.New("share").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<meta name="description" content="{{ .Cert.Student }} completed {{ .Cert.Course }} at {{ .Issuer.Name }}">
+<link rel="canonical" href="{{ .URL }}">
+<meta property="og:type" content="website">
+<meta property="og:site_name" content="{{ .Issuer.Name }}">
+<meta property="og:title" content="{{ .Title }}">
+<meta property="og:description" content="Issued {{ .Cert.IssueDate }} by {{ .Issuer.Name }}">
+<meta property="og:url" content="{{ .URL }}">
+<meta property="og:image" content="{{ .Image }}">
+<meta property="og:image:type" content="image/jpeg">
+<meta property="og:image:alt" content="Certificate of {{ .Cert.Student }}">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:title" content="{{ .Title }}">
+<meta name="twitter:description" content="Issued {{ .Cert.IssueDate }} by {{ .Issuer.Name }}">
+<meta name="twitter:image" content="{{ .Image }}">
+<style>
+body { margin: 0; font-family: system-ui, sans-serif; background: #f4f5f7; color: #1c1e21; }
+main { max-width: 680px; margin: 0 auto; padding: 24px 20px; text-align: center; }
+img { width: 100%; height: auto; border-radius: 4px; box-shadow: 0 2px 12px rgba(0, 0, 0, .15); }
+.actions a { display: inline-block; margin: 6px; padding: 10px 18px; border-radius: 4px; color: #fff; text-decoration: none; }
+.download { background: #00add8; }
+.linkedin { background: #0a66c2; }
+</style>
+</head>
+<body>
+<main>
+<img src="{{ .Preview }}" alt="Certificate of {{ .Cert.Student }}">
+<h1>{{ .Cert.Student }}</h1>
+<p>completed <strong>{{ .Cert.Course }}</strong></p>
+<p>Issued {{ .Cert.IssueDate }} by {{ with .Issuer.URL }}<a href="{{ . }}">{{ $.Issuer.Name }}</a>{{ else }}{{ .Issuer.Name }}{{ end }}</p>
+{{ with .Cert.ValidUntil }}<p>Valid until {{ date . "2 January 2006" }}</p>
+{{ end -}}
+<p class="actions">
+<a class="download" href="{{ .PDF }}" download>Download PDF</a>
+<a class="linkedin" href="{{ .LinkedIn }}" target="_blank" rel="noopener">Add to LinkedIn profile</a>
+</p>
+</main>
+</body>
+</html>
+`))
+
+// Links of certificate and other REST resources point to share page instead of PDF file, see SharePageHandler
+func (s *certsServer) LinkToSharePage() {
+	s.sharePage = true
+}
+
+func (s *certsServer) composeSharePageLink(id string) string {
+	return s.host + "c/" + id
+}
+
+// Handler of share page: HTML page of issued certificate with Open Graph and Twitter card tags,
+// its preview, PDF download and LinkedIn "Add to profile" buttons. Errors are written by error handler of mux.
+func (s *certsServer) SharePageHandler(mux *runtime.ServeMux, issuer Issuer) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		cert, err := s.issuedCertificate(r.Context(), pathParams["id"])
+		if err != nil {
+			httpError(mux, w, r, err)
+			return
+		}
+		link := s.composeSharePageLink(cert.Id)
+		page := sharePage{
+			Cert:     *cert,
+			Issuer:   issuer,
+			Title:    cert.Course + " - " + cert.Student,
+			URL:      link,
+			Image:    s.composeImageLink(cert.Id, shareImage),
+			Preview:  s.composeImageLink(cert.Id, sharePreview),
+			PDF:      s.composePDFLink(cert.Id),
+			LinkedIn: linkedInAddToProfileURL(cert, issuer, link),
+		}
+		b := bytes.Buffer{}
+		if err := sharePageTemplate.Execute(&b, page); err != nil {
+			httpError(mux, w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		http.ServeContent(w, r, "", cert.Timestamp, bytes.NewReader(b.Bytes()))
+	}
+}
+
+// URL of LinkedIn form adding certification to profile, prefilled with certificate.
+// Issue date is known only from validity window, as issue date of certificate is preformatted string.
+func linkedInAddToProfileURL(cert *Certificate, issuer Issuer, link string) string {
+	q := url.Values{
+		"startTask": {"CERTIFICATION_NAME"},
+		"name":      {cert.Course},
+		"certUrl":   {link},
+		"certId":    {cert.Id},
+	}
+	if issuer.LinkedInID != "" {
+		q.Set("organizationId", issuer.LinkedInID)
+	} else {
+		q.Set("organizationName", issuer.Name)
+	}
+	setMonth := func(prefix string, t *time.Time) {
+		if t != nil {
+			q.Set(prefix+"Year", strconv.Itoa(t.Year()))
+			q.Set(prefix+"Month", strconv.Itoa(int(t.Month())))
+		}
+	}
+	setMonth("issue", cert.ValidFrom)
+	setMonth("expiration", cert.ValidUntil)
+	return "https://www.linkedin.com/profile/add?" + q.Encode()
+}
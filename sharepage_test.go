@@ -0,0 +1,136 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+)
+
+func initTestSharePage(t *testing.T, issuer Issuer) (rMock *MockRegistry, mux *runtime.ServeMux) {
+	rMock = NewMockRegistry(t)
+	server := NewCertsServer(rMock, NewMockStorage(t), NewMockTemplater(t), host)
+	mux = runtime.NewServeMux()
+	if err := mux.HandlePath(http.MethodGet, "/c/{id}", server.SharePageHandler(mux, issuer)); err != nil {
+		assert.FailNow(t, "unexpected error while registering share page handler: %v", err)
+	}
+	return rMock, mux
+}
+
+func Test_SharePageHandler(t *testing.T) {
+	validUntil := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := Certificate{
+		Id:         "12345678",
+		Timestamp:  time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC),
+		Student:    "Test <Student>",
+		IssueDate:  "1 December 1999",
+		Course:     "Test Course",
+		ValidUntil: &validUntil,
+	}
+	issuer := Issuer{Name: "Test School", URL: "https://school.example.com/"}
+
+	t.Run("Page of issued certificate", func(t *testing.T) {
+		rMock, mux := initTestSharePage(t, issuer)
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(&cert, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/c/"+cert.Id, nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "text/html; charset=utf-8", resp.Header().Get("Content-Type"))
+		assert.Equal(t, cert.Timestamp.Format(http.TimeFormat), resp.Header().Get("Last-Modified"))
+		page := resp.Body.String()
+		assert.Contains(t, page, `<meta property="og:title" content="Test Course - Test &lt;Student&gt;">`)
+		assert.Contains(t, page, `<meta property="og:url" content="`+host+`c/12345678">`)
+		assert.Contains(t, page, `<meta property="og:image" content="`+host+`certificate/12345678?format=jpeg&amp;width=1200">`)
+		assert.Contains(t, page, `<meta name="twitter:card" content="summary_large_image">`)
+		assert.Contains(t, page, `<img src="`+host+`certificate/12345678?format=webp&amp;width=640"`)
+		assert.Contains(t, page, `<a class="download" href="`+host+`certificate/12345678" download>`)
+		assert.Contains(t, page, `<a href="https://school.example.com/">Test School</a>`)
+		assert.Contains(t, page, "Valid until 1 June 2025")
+		assert.Contains(t, page, `href="https://www.linkedin.com/profile/add?`)
+	})
+
+	t.Run("Revoked certificate", func(t *testing.T) {
+		rMock, mux := initTestSharePage(t, issuer)
+		revokedAt := time.Now()
+		revoked := cert
+		revoked.RevokedAt = &revokedAt
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(&revoked, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/c/"+cert.Id, nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "was revoked")
+	})
+
+	t.Run("Unknown certificate", func(t *testing.T) {
+		rMock, mux := initTestSharePage(t, issuer)
+		rMock.EXPECT().GetCertificate(mock.Anything, cert.Id).Return(nil, ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/c/"+cert.Id, nil)
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func Test_linkedInAddToProfileURL(t *testing.T) {
+	validFrom := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	cert := &Certificate{Id: "12345678", Course: "Test Course"}
+	link := host + "c/12345678"
+
+	t.Run("Organization by name", func(t *testing.T) {
+		u, err := url.Parse(linkedInAddToProfileURL(cert, Issuer{Name: "Test School"}, link))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "www.linkedin.com", u.Host)
+			assert.Equal(t, url.Values{
+				"startTask":        {"CERTIFICATION_NAME"},
+				"name":             {"Test Course"},
+				"organizationName": {"Test School"},
+				"certUrl":          {link},
+				"certId":           {"12345678"},
+			}, u.Query())
+		}
+	})
+	t.Run("Organization by id with validity window", func(t *testing.T) {
+		cert := *cert
+		cert.ValidFrom, cert.ValidUntil = &validFrom, &validUntil
+		u, err := url.Parse(linkedInAddToProfileURL(&cert, Issuer{Name: "Test School", LinkedInID: "1337"}, link))
+		if assert.NoError(t, err) {
+			q := u.Query()
+			assert.Equal(t, "1337", q.Get("organizationId"))
+			assert.Empty(t, q.Get("organizationName"))
+			assert.Equal(t, "2022", q.Get("issueYear"))
+			assert.Equal(t, "3", q.Get("issueMonth"))
+			assert.Equal(t, "2024", q.Get("expirationYear"))
+			assert.Equal(t, "11", q.Get("expirationMonth"))
+		}
+	})
+}
+
+func Test_LinkToSharePage(t *testing.T) {
+	rMock := NewMockRegistry(t)
+	server := NewCertsServer(rMock, NewMockStorage(t), NewMockTemplater(t), host)
+	assert.Equal(t, host+"certificate/12345678", server.composeCertificateLink("12345678"))
+
+	server.LinkToSharePage()
+	rMock.EXPECT().GetCertificate(mock.Anything, "12345678").Return(&Certificate{Id: "12345678"}, nil)
+	got, err := server.GetCertificateLink(context.Background(), &api.GetCertificateLinkRequest{Id: "12345678"})
+	assert.NoError(t, err)
+	assert.Equal(t, host+"c/12345678", got.GetLink())
+	// PDF file is still downloaded from its own link
+	assert.Equal(t, host+"certificate/12345678", server.composePDFLink("12345678"))
+}
@@ -0,0 +1,103 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/c2fo/vfs/v6/backend/gs"
+	"github.com/c2fo/vfs/v6/backend/s3"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// Storage able to give out time-limited URLs of certificate files, so clients download them from backend directly
+type URLSigner interface {
+	// Empty URL means certificate isn't stored or backend can't sign URLs
+	SignedURL(id string, timestamp time.Time, ttl time.Duration) (string, error)
+}
+
+// Metadata key set by gateway for REST requests, only they are answered with redirects
+const acceptRedirectMetadataKey = "x-accept-redirect"
+
+// Header metadata key of URL REST client is redirected to, see RedirectForwardResponse
+const redirectLocationMetadataKey = "x-redirect-location"
+
+var _ URLSigner = &VfsStorage{}
+
+// Signs URL of certificate file in s3 and gs backends, others can't sign URLs
+func (s *VfsStorage) SignedURL(id string, timestamp time.Time, ttl time.Duration) (string, error) {
+	cl, ok := s.diskCache.Peek(id)
+	if !ok || !(cl.timestamp.Equal(timestamp) || cl.timestamp.After(timestamp)) {
+		return "", nil
+	}
+	key := strings.TrimPrefix(cl.absPath, "/")
+	switch fs := s.fs.(type) {
+	case *s3.FileSystem:
+		client, err := fs.Client()
+		if err != nil {
+			return "", fmt.Errorf("failed to get s3 client: %w", err)
+		}
+		req, _ := client.GetObjectRequest(&awss3.GetObjectInput{
+			Bucket:              aws.String(s.volume),
+			Key:                 aws.String(key),
+			ResponseContentType: aws.String("application/pdf"),
+		})
+		u, err := req.Presign(ttl)
+		if err != nil {
+			return "", fmt.Errorf("failed to presign s3 request: %w", err)
+		}
+		return u, nil
+	case *gs.FileSystem:
+		client, err := fs.Client()
+		if err != nil {
+			return "", fmt.Errorf("failed to get gs client: %w", err)
+		}
+		// Signing credentials are detected from client
+		u, err := client.Bucket(s.volume).SignedURL(key, &storage.SignedURLOptions{
+			Method:          http.MethodGet,
+			Expires:         s.now().Add(ttl),
+			Scheme:          storage.SigningSchemeV4,
+			QueryParameters: url.Values{"response-content-type": {"application/pdf"}},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to sign gs url: %w", err)
+		}
+		return u, nil
+	}
+	return "", nil
+}
+
+// Marks REST requests as accepting redirects, pass to runtime.WithMetadata
+func RedirectMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(acceptRedirectMetadataKey, "true")
+}
+
+func acceptsRedirect(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(acceptRedirectMetadataKey)) != 0
+}
+
+// Answers REST request with 302 Found when server set redirect location, pass to runtime.WithForwardResponseOption
+func RedirectForwardResponse(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	location := md.HeaderMD.Get(redirectLocationMetadataKey)
+	if len(location) == 0 {
+		return nil
+	}
+	w.Header().Del(runtime.MetadataHeaderPrefix + redirectLocationMetadataKey)
+	w.Header().Del("Content-Type")
+	w.Header().Set("Location", location[0])
+	w.WriteHeader(http.StatusFound)
+	return nil
+}
@@ -0,0 +1,84 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/c2fo/vfs/v6/backend/mem"
+	"github.com/c2fo/vfs/v6/backend/s3"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_VfsStorage_SignedURL(t *testing.T) {
+	ts := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+	cert := []byte{1, 2, 3}
+
+	t.Run("Presign s3 request", func(t *testing.T) {
+		s, err := NewVfsStorage("bucket", "/certs/", s3.Scheme, nil, 0)
+		if err != nil {
+			assert.FailNow(t, "unexpected error: %v", err)
+		}
+		sess := session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String("eu-central-1"),
+			Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+		}))
+		s.fs = s.fs.(*s3.FileSystem).WithClient(awss3.New(sess))
+		// Certificate is linked directly, nothing is written to s3
+		s.diskCache.Add("id", *composeTestCertLink(ts, "/certs/", cert))
+
+		got, err := s.SignedURL("id", ts, 10*time.Minute)
+		assert.NoError(t, err)
+		u, err := url.Parse(got)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "bucket.s3.eu-central-1.amazonaws.com", u.Host)
+			assert.Equal(t, "/certs/"+blobName(checksum(cert), FormatPDF), u.Path)
+			assert.Equal(t, "600", u.Query().Get("X-Amz-Expires"))
+			assert.Equal(t, "application/pdf", u.Query().Get("response-content-type"))
+		}
+
+		got, err = s.SignedURL("id", ts.Add(time.Hour), 10*time.Minute)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Backend can't sign URLs", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testsignedurl/")
+		assert.NoError(t, s.Add("id", ts, &cert))
+		got, err := s.SignedURL("id", ts, 10*time.Minute)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func Test_RedirectForwardResponse(t *testing.T) {
+	t.Run("Redirect to location set by server", func(t *testing.T) {
+		md := runtime.ServerMetadata{HeaderMD: metadata.Pairs(redirectLocationMetadataKey, "https://bucket.example.com/cert.pdf")}
+		ctx := runtime.NewServerMetadataContext(context.Background(), md)
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set(runtime.MetadataHeaderPrefix+redirectLocationMetadataKey, "https://bucket.example.com/cert.pdf")
+
+		assert.NoError(t, RedirectForwardResponse(ctx, w, nil))
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://bucket.example.com/cert.pdf", w.Header().Get("Location"))
+		assert.Empty(t, w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get(runtime.MetadataHeaderPrefix+redirectLocationMetadataKey))
+	})
+
+	t.Run("Response without location is untouched", func(t *testing.T) {
+		ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+		w := httptest.NewRecorder()
+		assert.NoError(t, RedirectForwardResponse(ctx, w, nil))
+		assert.Empty(t, w.Header().Get("Location"))
+	})
+}
@@ -1,51 +1,66 @@
 package golangunitedschoolcerts
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
+	"log"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
 	"github.com/c2fo/vfs/v6"
 	"github.com/c2fo/vfs/v6/backend"
+	"github.com/c2fo/vfs/v6/backend/azure"
+	"github.com/c2fo/vfs/v6/backend/gs"
 	"github.com/c2fo/vfs/v6/backend/mem"
 	"github.com/c2fo/vfs/v6/backend/os"
 	"github.com/c2fo/vfs/v6/backend/s3"
-	"github.com/c2fo/vfs/v6/vfssimple"
+	"github.com/c2fo/vfs/v6/backend/sftp"
+	"google.golang.org/api/option"
 )
 
 type Storage interface {
 	Add(string, time.Time, *[]byte) error
 	Get(string, time.Time) (*[]byte, error)
+	// Streams certificate file instead of reading it whole, reader is io.Seeker when storage can seek
+	Open(string, time.Time) (io.ReadCloser, error)
 	Contains(string, time.Time) bool
 	Delete(string, time.Time)
 	Load() error
+	// SHA-256 of stored certificate file in hex
+	Checksum(string, time.Time) (string, bool)
 }
 
+// Storage keeping certificate files in vfs backend, tier of TieredStorage
 type VfsStorage struct {
 	fs        vfs.FileSystem
 	volume    string
 	basePath  string
-	memCache  Cache[string, certFile]
 	diskCache Cache[string, certLink]
+	// Last access of certificates in diskCache, persisted by SaveIndex
+	accessMu sync.Mutex
+	accessed map[string]time.Time
+	now      func() time.Time
+	// Number of certificates in diskCache referencing each file by its checksum
+	blobMu sync.Mutex
+	refs   map[string]int
 }
 
-type certFile struct {
-	timestamp time.Time
-	file      []byte
-}
-
-// Primitive counter for now
-func (c certFile) Size() int {
-	return 1
-}
-
+// Certificate file named by checksum of its content, shared by all certificates with the same content
 type certLink struct {
 	timestamp time.Time
 	// for use with fs directly
-	absPath string
-	// for use with vfssimple
-	uri string
+	absPath  string
+	size     int
+	checksum string
 }
 
 // Primitive counter for now
@@ -53,17 +68,16 @@ func (c certLink) Size() int {
 	return 1
 }
 
-func NewVfsStorage(volume string, basePath string, scheme string, opts *vfs.Options) (s *VfsStorage, err error) {
+// Keeps up to capacity certificates in backend, zero means unlimited
+func NewVfsStorage(volume string, basePath string, scheme string, opts *vfs.Options, capacity int) (s *VfsStorage, err error) {
 	s = &VfsStorage{
 		volume:   volume,
 		basePath: basePath,
+		accessed: map[string]time.Time{},
+		now:      time.Now,
+		refs:     map[string]int{},
 	}
-	if c, err := NewLRUCache[string, certFile](0, nil); err != nil {
-		return nil, fmt.Errorf("failed to create memCache: %w", err)
-	} else {
-		s.memCache = NewSafeCache[string, certFile](c)
-	}
-	if c, err := NewLRUCache(0, onLinkEviction); err != nil {
+	if c, err := NewLRUCache(capacity, s.onLinkEviction); err != nil {
 		return nil, fmt.Errorf("failed to create diskCache: %w", err)
 	} else {
 		s.diskCache = NewSafeCache[string, certLink](c)
@@ -82,10 +96,51 @@ func InitBackend(scheme string, opts *vfs.Options) (fs vfs.FileSystem, err error
 			return nil, fmt.Errorf("unknown options: %v, for scheme: %v", opts, scheme)
 		}
 	case s3.Scheme:
-		fs = backend.Backend(s3.Scheme)
+		// Own instance, registered one is shared by whole process
+		s3fs := s3.NewFileSystem()
 		if opts != nil {
-			fs = fs.(*s3.FileSystem).WithOptions(opts)
+			s3fs = s3fs.WithOptions(*opts)
+		}
+		fs = s3fs
+	case gs.Scheme:
+		gsfs := gs.NewFileSystem()
+		if opts != nil {
+			o, ok := (*opts).(gs.Options)
+			if !ok {
+				return nil, fmt.Errorf("unexpected options: %T, for scheme: %v", *opts, scheme)
+			}
+			// vfs applies only the first of set options, so client is created here with all of them
+			client, err := storage.NewClient(context.Background(), gsClientOptions(o)...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create gs client: %w", err)
+			}
+			gsfs = gsfs.WithOptions(o).WithClient(client)
+		}
+		fs = gsfs
+	case AzureScheme:
+		// Options are read from VFS_AZURE_* environment variables unless provided
+		azfs := azure.NewFileSystem()
+		if opts != nil {
+			o, ok := (*opts).(AzureOptions)
+			if !ok {
+				return nil, fmt.Errorf("unexpected options: %T, for scheme: %v", *opts, scheme)
+			}
+			azfs = azfs.WithOptions(o.Options)
+			if o.Endpoint != "" {
+				client, err := newAzureEndpointClient(&o.Options, o.Endpoint)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create azure client: %w", err)
+				}
+				azfs = azfs.WithClient(client)
+			}
 		}
+		fs = azfs
+	case sftp.Scheme:
+		sftpfs := sftp.NewFileSystem()
+		if opts != nil {
+			sftpfs = sftpfs.WithOptions(*opts)
+		}
+		fs = sftpfs
 	// im memory implementation for testing purposes
 	case mem.Scheme:
 		fs = backend.Backend(mem.Scheme)
@@ -98,40 +153,122 @@ func InitBackend(scheme string, opts *vfs.Options) (fs vfs.FileSystem, err error
 	return fs, nil
 }
 
+func gsClientOptions(o gs.Options) []option.ClientOption {
+	var opts []option.ClientOption
+	if o.APIKey != "" {
+		opts = append(opts, option.WithAPIKey(o.APIKey))
+	}
+	if o.CredentialFile != "" {
+		opts = append(opts, option.WithCredentialsFile(o.CredentialFile))
+	}
+	if o.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(o.Endpoint))
+	}
+	if len(o.Scopes) != 0 {
+		opts = append(opts, option.WithScopes(o.Scopes...))
+	}
+	return opts
+}
+
+// Releases file of evicted certificate, deleting it through configured backend when no other certificate references it
 // TODO: add some sort of job queue with retries in another goroutine
-func onLinkEviction(key *string, value *certLink) {
-	f, _ := vfssimple.NewFile(value.uri)
-	f.Delete()
-	f.Close()
+func (s *VfsStorage) onLinkEviction(key *string, value *certLink) {
+	s.accessMu.Lock()
+	delete(s.accessed, *key)
+	s.accessMu.Unlock()
+	s.release(value.checksum, value.absPath)
+}
+
+func (s *VfsStorage) release(sum string, absPath string) {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	if s.refs[sum]--; s.refs[sum] > 0 {
+		return
+	}
+	delete(s.refs, sum)
+	f, err := s.fs.NewFile(s.volume, absPath)
+	if err == nil {
+		err = f.Delete()
+	}
+	if err != nil {
+		log.Printf("Failed to delete evicted certificate file %s: %v", absPath, err)
+	}
+}
+
+// Name of certificate file relative to basePath, extension tells format of its content
+func blobName(sum string, f Format) string {
+	return sum + "." + string(f)
+}
+
+// Checksum of content of file named by blobName. Files written before extension followed format are named .pdf.
+func blobSum(name string) (string, bool) {
+	i := strings.LastIndex(name, ".")
+	if i == -1 {
+		return "", false
+	}
+	sum := name[:i]
+	if _, ok := formatContentTypes[Format(name[i+1:])]; !ok || len(sum) != sha256.Size*2 {
+		return "", false
+	}
+	_, err := hex.DecodeString(sum)
+	return sum, err == nil && strings.ToLower(sum) == sum
+}
+
+func isBlobName(name string) bool {
+	_, ok := blobSum(name)
+	return ok
 }
 
+// Writes certificate file under its checksum. File is written even when it is already referenced,
+// overwriting it with the same content heals one damaged in backend.
 func (s *VfsStorage) Add(id string, timestamp time.Time, cert *[]byte) error {
 	cl, ok := s.diskCache.Peek(id)
 	if ok && (cl.timestamp.Equal(timestamp) || cl.timestamp.After(timestamp)) {
 		return nil
 	}
-	file, err := s.fs.NewFile(s.volume, s.basePath+id+"_"+timestamp.String()+".pdf")
+	sum := checksum(*cert)
+	path, err := s.retain(sum, keyFormat(id), *cert)
 	if err != nil {
-		return fmt.Errorf("failed to initialize file: %w", err)
+		return err
 	}
-	_, err = file.Write(*cert)
+	// Replaced link of the same content only decrements references, so file is kept
+	s.diskCache.Add(id, certLink{timestamp, path, len(*cert), sum})
+	s.touch(id)
+	return nil
+}
+
+// References file with checksum sum, writing content to it. It is written under blobMu,
+// so concurrent eviction of the same content never deletes it in between.
+func (s *VfsStorage) retain(sum string, f Format, content []byte) (string, error) {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	file, err := s.fs.NewFile(s.volume, s.basePath+blobName(sum, f))
 	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return "", fmt.Errorf("failed to initialize file: %w", err)
 	}
-	err = file.Close()
-	if err != nil {
-		return fmt.Errorf("failed to close file: %w", err)
+	if _, err = file.Write(content); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
 	}
-	s.diskCache.Add(id, certLink{timestamp, file.Path(), file.URI()})
-	return nil
+	if err = file.Close(); err != nil {
+		return "", fmt.Errorf("failed to close file: %w", err)
+	}
+	s.refs[sum]++
+	return file.Path(), nil
+}
+
+// Records access of certificate for index
+func (s *VfsStorage) touch(id string) {
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	s.accessed[id] = s.now()
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
 }
 
 func (s *VfsStorage) Get(id string, timestamp time.Time) (cert *[]byte, err error) {
-	cf, ok := s.memCache.Peek(id)
-	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.After(timestamp)) {
-		s.memCache.Touch(id)
-		return &cf.file, nil
-	}
 	cl, ok := s.diskCache.Peek(id)
 	if ok && (cl.timestamp.Equal(timestamp) || cl.timestamp.After(timestamp)) {
 		file, err := s.fs.NewFile(s.volume, cl.absPath)
@@ -146,76 +283,317 @@ func (s *VfsStorage) Get(id string, timestamp time.Time) (cert *[]byte, err erro
 		if err != nil {
 			return nil, fmt.Errorf("failed to close file: %w", err)
 		}
+		if checksum(c) != cl.checksum {
+			// Certificate is generated again and overwrites corrupted file
+			s.diskCache.Remove(id)
+			return nil, fmt.Errorf("checksum mismatch of certificate file for id: %v", id)
+		}
 		s.diskCache.Touch(id)
-		s.memCache.Add(id, certFile{cl.timestamp, c})
+		s.touch(id)
 		return &c, err
 	}
 	return nil, fmt.Errorf("no certificate file found for such id: %v and timestamp: %v", id, timestamp)
 }
 
-func (s *VfsStorage) Contains(id string, timestamp time.Time) bool {
-	cf, ok := s.memCache.Peek(id)
-	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.After(timestamp)) {
-		return true
+// Opens certificate file for streaming. Checksum is verified when file is read from the start to the end,
+// reader returns error instead of io.EOF at the end of corrupted file.
+func (s *VfsStorage) Open(id string, timestamp time.Time) (io.ReadCloser, error) {
+	cl, ok := s.diskCache.Peek(id)
+	if !ok || !(cl.timestamp.Equal(timestamp) || cl.timestamp.After(timestamp)) {
+		return nil, fmt.Errorf("no certificate file found for such id: %v and timestamp: %v", id, timestamp)
 	}
+	file, err := s.fs.NewFile(s.volume, cl.absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize file: %w", err)
+	}
+	s.diskCache.Touch(id)
+	s.touch(id)
+	return &verifiedFile{
+		file:       file,
+		size:       int64(cl.size),
+		checksum:   cl.checksum,
+		hash:       sha256.New(),
+		sequential: true,
+		// Certificate is generated again and overwrites corrupted file
+		onMismatch: func() { s.diskCache.Remove(id) },
+	}, nil
+}
+
+// Certificate file verifying its checksum while it is read sequentially.
+// Position is tracked here and file is seeked only before reading, as mem backend can't seek to the end of file.
+type verifiedFile struct {
+	file     io.ReadSeekCloser
+	size     int64
+	checksum string
+	hash     hash.Hash
+	// Position of reader and of file
+	pos, filePos int64
+	// Hash covers everything read so far, false once file is read from other position than the start
+	sequential bool
+	onMismatch func()
+}
+
+func (f *verifiedFile) Read(p []byte) (n int, err error) {
+	if f.pos >= f.size {
+		err = io.EOF
+	} else {
+		if f.filePos != f.pos {
+			if f.filePos, err = f.file.Seek(f.pos, io.SeekStart); err != nil {
+				return 0, err
+			}
+		}
+		// Corrupted file may be longer than certificate
+		if int64(len(p)) > f.size-f.pos {
+			p = p[:f.size-f.pos]
+		}
+		n, err = f.file.Read(p)
+		f.pos += int64(n)
+		f.filePos = f.pos
+	}
+	if !f.sequential {
+		return n, err
+	}
+	f.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(f.hash.Sum(nil)) != f.checksum {
+		f.sequential = false
+		f.onMismatch()
+		return n, fmt.Errorf("checksum mismatch of certificate file %s", f.checksum)
+	}
+	return n, err
+}
+
+// Seeking to the start restarts verification, e.g. http.ServeContent seeks to the end for size and back
+func (f *verifiedFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.size
+	}
+	if offset < 0 {
+		return f.pos, fmt.Errorf("invalid seek to negative position %d", offset)
+	}
+	f.pos = offset
+	if f.pos == 0 {
+		f.hash.Reset()
+	}
+	f.sequential = f.pos == 0
+	return f.pos, nil
+}
+
+func (f *verifiedFile) Close() error {
+	return f.file.Close()
+}
+
+func (s *VfsStorage) Contains(id string, timestamp time.Time) bool {
+	cl, ok := s.diskCache.Peek(id)
+	return ok && (cl.timestamp.Equal(timestamp) || cl.timestamp.After(timestamp))
+}
+
+func (s *VfsStorage) Checksum(id string, timestamp time.Time) (string, bool) {
 	cl, ok := s.diskCache.Peek(id)
 	if ok && (cl.timestamp.Equal(timestamp) || cl.timestamp.After(timestamp)) {
-		return true
+		return cl.checksum, true
 	}
-	return false
+	return "", false
 }
 
 func (s *VfsStorage) Delete(id string, timestamp time.Time) {
-	cf, ok := s.memCache.Peek(id)
-	if ok && (cf.timestamp.Equal(timestamp) || cf.timestamp.Before(timestamp)) {
-		s.memCache.Remove(id)
-	}
 	cl, ok := s.diskCache.Peek(id)
 	if ok && (cl.timestamp.Equal(timestamp) || cl.timestamp.Before(timestamp)) {
 		s.diskCache.Remove(id)
 	}
 }
 
-func (s *VfsStorage) Load() error {
-	const (
-		HEAD = 8
-		TAIL = 4
-	)
-	m := make(map[string][]string)
+// Checks that backend is writable by writing and deleting probe file.
+// Probe is kept out of basePath, so Load never sees it.
+func (s *VfsStorage) CheckHealth(ctx context.Context) error {
+	file, err := s.fs.NewFile(s.volume, s.basePath+".health/probe")
+	if err != nil {
+		return fmt.Errorf("failed to initialize probe file: %w", err)
+	}
+	if _, err = file.Write([]byte("ok")); err != nil {
+		return fmt.Errorf("failed to write probe file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close probe file: %w", err)
+	}
+	if err = file.Delete(); err != nil {
+		return fmt.Errorf("failed to delete probe file: %w", err)
+	}
+	return nil
+}
 
+// Fills diskCache with certificates of index found under basePath, restoring their LRU order.
+// Files are named by checksum of content, so only index maps them to certificates. Unindexed ones are kept,
+// they may be written after index was saved or belong to another instance sharing basePath.
+// Files named id_timestamp.pdf, written before content addressing, are moved under their checksums.
+// When several files have the same id, the latest one is kept.
+func (s *VfsStorage) Load() error {
 	loc, err := s.fs.NewLocation(s.volume, s.basePath)
 	if err != nil {
 		return fmt.Errorf("failed to set up location: %w", err)
 	}
-	fDir, err := loc.List()
+	names, err := s.list(loc)
 	if err != nil {
 		return fmt.Errorf("failed to get file list: %w", err)
 	}
-	// fill out file list map
-	for _, f := range fDir {
-		timestamp := f[HEAD+1 : len(f)-TAIL]
-		m[timestamp] = append(m[timestamp], f)
-	}
-	// sort files
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	// add files to storage
-	layout := "2006-01-02 15:04:05.999999999 -0700 MST"
-	for _, timestamp := range keys {
-		ts, err := time.Parse(layout, timestamp)
+	index, err := s.readIndex(loc)
+	if err != nil {
+		return err
+	}
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[name] = true
+	}
+
+	latest := map[string]storageIndexEntry{}
+	var outdated []string
+	keep := func(e storageIndexEntry) {
+		prev, ok := latest[e.Id]
+		switch {
+		case !ok:
+			latest[e.Id] = e
+		case prev.Timestamp.Before(e.Timestamp):
+			latest[e.Id] = e
+			outdated = append(outdated, prev.File)
+		default:
+			outdated = append(outdated, e.File)
+		}
+	}
+	indexed := map[string]bool{}
+	for _, e := range index {
+		if present[e.File] {
+			keep(e)
+			indexed[e.File] = true
+		}
+	}
+	for _, name := range names {
+		if id, ts, ok := parseCertFileName(name); ok && !indexed[name] {
+			keep(storageIndexEntry{Id: id, Timestamp: ts, File: name})
+		}
+	}
+	// Content of outdated certificate may still be referenced by another one
+	for _, name := range outdated {
+		if isBlobName(name) {
+			continue
+		}
+		if err := loc.DeleteFile(name); err != nil {
+			log.Printf("Failed to delete outdated certificate file %s: %v", name, err)
+		}
+	}
+
+	entries := make([]storageIndexEntry, 0, len(latest))
+	for _, e := range latest {
+		if sum, ok := blobSum(e.File); ok {
+			e.Checksum = sum
+		} else if e, err = s.migrate(loc, e); err != nil {
+			log.Printf("Failed to move certificate file under its checksum: %v", err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].LastAccess.Equal(entries[j].LastAccess) {
+			return entries[i].LastAccess.Before(entries[j].LastAccess)
+		}
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	for _, e := range entries {
+		file, err := loc.NewFile(e.File)
 		if err != nil {
-			return fmt.Errorf("failed to convert date to time.Time: %w", err)
+			return fmt.Errorf("failed to initialize file: %w", err)
 		}
-		for _, mk := range m[timestamp] {
-			file, err := loc.NewFile(mk)
-			if err != nil {
-				return fmt.Errorf("failed to initialize file: %w", err)
-			}
-			s.diskCache.Add(mk[:HEAD], certLink{ts, file.Path(), file.URI()})
+		s.blobMu.Lock()
+		s.refs[e.Checksum]++
+		s.blobMu.Unlock()
+		s.diskCache.Add(e.Id, certLink{e.Timestamp, file.Path(), e.Size, e.Checksum})
+		if !e.LastAccess.IsZero() {
+			s.accessMu.Lock()
+			s.accessed[e.Id] = e.LastAccess
+			s.accessMu.Unlock()
 		}
 	}
 	return nil
 }
+
+// Moves file named id_timestamp.pdf under checksum of its content
+func (s *VfsStorage) migrate(loc vfs.Location, e storageIndexEntry) (storageIndexEntry, error) {
+	file, err := loc.NewFile(e.File)
+	if err != nil {
+		return e, fmt.Errorf("failed to initialize file %s: %w", e.File, err)
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return e, fmt.Errorf("failed to read file %s: %w", e.File, err)
+	}
+	if err = file.Close(); err != nil {
+		return e, fmt.Errorf("failed to close file %s: %w", e.File, err)
+	}
+	sum := checksum(content)
+	// Only PDF files were written before content addressing
+	blob, err := loc.NewFile(blobName(sum, FormatPDF))
+	if err != nil {
+		return e, fmt.Errorf("failed to initialize file %s: %w", blobName(sum, FormatPDF), err)
+	}
+	if _, err = blob.Write(content); err != nil {
+		return e, fmt.Errorf("failed to write file %s: %w", blob.Name(), err)
+	}
+	if err = blob.Close(); err != nil {
+		return e, fmt.Errorf("failed to close file %s: %w", blob.Name(), err)
+	}
+	if err = file.Delete(); err != nil {
+		log.Printf("Failed to delete moved certificate file %s: %v", e.File, err)
+	}
+	e.File, e.Size, e.Checksum = blob.Name(), len(content), sum
+	return e, nil
+}
+
+// Names of files directly in location. S3 is listed page by page with ListObjectsV2 by configured client,
+// since listing of vfs depends on NextMarker, which S3-compatible stores may omit.
+func (s *VfsStorage) list(loc vfs.Location) ([]string, error) {
+	fs, ok := s.fs.(*s3.FileSystem)
+	if !ok {
+		return loc.List()
+	}
+	client, err := fs.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get s3 client: %w", err)
+	}
+	prefix := strings.TrimPrefix(loc.Path(), "/")
+	input := &awss3.ListObjectsV2Input{
+		Bucket:    aws.String(loc.Volume()),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	var names []string
+	err = client.ListObjectsV2Pages(input, func(page *awss3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			if name := strings.TrimPrefix(aws.StringValue(o.Key), prefix); name != "" {
+				names = append(names, name)
+			}
+		}
+		return true
+	})
+	return names, err
+}
+
+// Splits name of file written by Add before content addressing into certificate id and timestamp
+func parseCertFileName(name string) (id string, timestamp time.Time, ok bool) {
+	const layout = "2006-01-02 15:04:05.999999999 -0700 MST"
+	id, rest, found := strings.Cut(name, "_")
+	if !found || id == "" || !strings.HasSuffix(rest, ".pdf") {
+		return "", time.Time{}, false
+	}
+	rest = strings.TrimSuffix(rest, ".pdf")
+	// Times with monotonic clock reading are formatted with it
+	if i := strings.Index(rest, " m="); i != -1 {
+		rest = rest[:i]
+	}
+	timestamp, err := time.Parse(layout, rest)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return id, timestamp, true
+}
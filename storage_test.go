@@ -1,15 +1,23 @@
 package golangunitedschoolcerts
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/c2fo/vfs/v6"
+	"github.com/c2fo/vfs/v6/backend/azure"
+	"github.com/c2fo/vfs/v6/backend/gs"
 	"github.com/c2fo/vfs/v6/backend/mem"
+	"github.com/c2fo/vfs/v6/backend/s3"
+	"github.com/c2fo/vfs/v6/backend/sftp"
 	"github.com/c2fo/vfs/v6/mocks"
-	"github.com/c2fo/vfs/v6/vfssimple"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,24 +36,24 @@ func (fs fsCountingCalls) NewFile(volume string, absFilePath string) (vfs.File,
 }
 
 func createTestStorage(t *testing.T, scheme string, basePath string) (s *VfsStorage) {
-	s, err := NewVfsStorage("", basePath, scheme, nil)
+	s, err := NewVfsStorage("", basePath, scheme, nil, 0)
 	if err != nil {
 		assert.FailNow(t, "unexpected error creating inmemory storage: %v", err)
 	}
 	return s
 }
 
-func composeTestCertLink(id string, timestamp time.Time, scheme string, basePath string) *certLink {
-	expFileName := "id" + "_" + timestamp.String() + ".pdf"
+func composeTestCertLink(timestamp time.Time, basePath string, cert []byte) *certLink {
 	return &certLink{
 		timestamp: timestamp,
-		absPath:   basePath + expFileName,
-		uri:       scheme + "://" + basePath + expFileName,
+		absPath:   basePath + blobName(checksum(cert), FormatPDF),
+		size:      len(cert),
+		checksum:  checksum(cert),
 	}
 }
 
-func testLinkedCertEqual(t *testing.T, expected []byte, actual *certLink) {
-	f, err := vfssimple.NewFile(actual.uri)
+func testLinkedCertEqual(t *testing.T, s *VfsStorage, expected []byte, actual *certLink) {
+	f, err := s.fs.NewFile(s.volume, actual.absPath)
 	if err != nil {
 		assert.FailNow(t, "unexpected error: %v", err)
 	}
@@ -71,18 +79,36 @@ func Test_VfsStorage_Add(t *testing.T) {
 		id := "id"
 		expCert := []byte{1, 1, 1, 1}
 		now := time.Now()
-		expCertLink := composeTestCertLink(id, now, scheme, basePath)
+		expCertLink := composeTestCertLink(now, basePath, expCert)
 
 		err := s.Add(id, now, &expCert)
 		assert.NoError(t, err)
 
 		v, ok := s.diskCache.Peek("id")
 		if assert.True(t, ok) {
-			testLinkedCertEqual(t, expCert, v)
+			testLinkedCertEqual(t, s, expCert, v)
 		}
 		assert.Equal(t, expCertLink, v)
 	})
 
+	t.Run("File of image variant is named by its format", func(t *testing.T) {
+		s := createTestStorage(t, scheme, basePath)
+		img := []byte{2, 2, 2, 2}
+		assert.NoError(t, s.Add(Variant{Format: FormatWEBP, Width: 320}.key("id"), time.Now(), &img))
+		name := checksum(img) + ".webp"
+		assert.True(t, fileExists(t, s, name))
+		assert.False(t, fileExists(t, s, checksum(img)+".pdf"))
+
+		// Index restores checksum of image files too
+		assert.NoError(t, s.SaveIndex())
+		s2 := createTestStorage(t, scheme, basePath)
+		assert.NoError(t, s2.Load())
+		cl, ok := s2.diskCache.Peek("id.webp-320")
+		if assert.True(t, ok) {
+			assert.Equal(t, checksum(img), cl.checksum)
+		}
+	})
+
 	t.Run("Cache hits after attempt to add same or older certificate", func(t *testing.T) {
 		s := createTestStorage(t, scheme, basePath)
 		calls := 0
@@ -91,7 +117,7 @@ func Test_VfsStorage_Add(t *testing.T) {
 		id := "id"
 		expCert := []byte{1, 1, 1, 1}
 		now := time.Now()
-		expCertLink := composeTestCertLink(id, now, scheme, basePath)
+		expCertLink := composeTestCertLink(now, basePath, expCert)
 
 		for i := 0; i < 10; i++ {
 			err := s.Add(id, now, &expCert)
@@ -109,7 +135,7 @@ func Test_VfsStorage_Add(t *testing.T) {
 
 		v, ok := s.diskCache.Peek(id)
 		if assert.True(t, ok) {
-			testLinkedCertEqual(t, expCert, v)
+			testLinkedCertEqual(t, s, expCert, v)
 		}
 		assert.Equal(t, expCertLink, v)
 	})
@@ -122,7 +148,7 @@ func Test_VfsStorage_Add(t *testing.T) {
 		id := "id"
 		cert := []byte{1, 1, 1, 1}
 		now := time.Now()
-		oldCertLink := composeTestCertLink(id, now, scheme, basePath)
+		oldCertLink := composeTestCertLink(now, basePath, cert)
 
 		err := s.Add(id, now, &cert)
 		assert.NoError(t, err)
@@ -131,27 +157,50 @@ func Test_VfsStorage_Add(t *testing.T) {
 
 		newCert := []byte{2, 2, 2, 2}
 		newTime := time.Now()
-		expCertLink := composeTestCertLink(id, newTime, scheme, basePath)
+		expCertLink := composeTestCertLink(newTime, basePath, newCert)
 
 		err = s.Add("id", newTime, &newCert)
 		assert.NoError(t, err)
-		// check that there was only two fs.NewFile calls
-		assert.Equal(t, 2, calls)
+		// check that there were two fs.NewFile calls for writing and one for deleting old file
+		assert.Equal(t, 3, calls)
 
 		v, ok := s.diskCache.Peek("id")
 		if assert.True(t, ok) {
-			testLinkedCertEqual(t, newCert, expCertLink)
+			testLinkedCertEqual(t, s, newCert, expCertLink)
 		}
 		assert.Equal(t, expCertLink, v)
 		assert.Equal(t, 1, s.diskCache.Len())
 
 		// check that old file doesn't exists anymore on vfs backend
-		f, err := vfssimple.NewFile(oldCertLink.uri)
+		f, err := s.fs.NewFile(s.volume, oldCertLink.absPath)
 		assert.NoError(t, err, "unexpected error")
 		b, err := f.Exists()
 		assert.NoError(t, err, "unexpected error")
 		assert.False(t, b)
 	})
+	t.Run("Certificates with the same content share file", func(t *testing.T) {
+		s := createTestStorage(t, scheme, "/testdedupe/")
+		cert := []byte{3, 3, 3, 3}
+		now := time.Now()
+		assert.NoError(t, s.Add("id1", now, &cert))
+		assert.NoError(t, s.Add("id2", now, &cert))
+		// Re-rendered certificate with the same content keeps its file
+		assert.NoError(t, s.Add("id2", now.Add(time.Hour), &cert))
+		v1, _ := s.diskCache.Peek("id1")
+		v2, _ := s.diskCache.Peek("id2")
+		assert.Equal(t, v1.absPath, v2.absPath)
+		assert.Equal(t, 2, s.refs[v1.checksum])
+
+		s.Delete("id1", now)
+		testLinkedCertEqual(t, s, cert, v2)
+		s.Delete("id2", now.Add(time.Hour))
+		f, err := s.fs.NewFile(s.volume, v2.absPath)
+		assert.NoError(t, err, "unexpected error")
+		b, err := f.Exists()
+		assert.NoError(t, err, "unexpected error")
+		assert.False(t, b)
+		assert.Empty(t, s.refs)
+	})
 	t.Run("vfs return errors", func(t *testing.T) {
 		s := createTestStorage(t, scheme, basePath)
 
@@ -198,18 +247,6 @@ func Test_VfsStorage_Get(t *testing.T) {
 		assert.Error(t, err)
 	})
 
-	t.Run("Get a new certificate from the storage (memCash used)", func(t *testing.T) {
-		s := createTestStorage(t, scheme, basePath)
-
-		expCert := []byte{1, 1, 1, 1}
-		now := time.Now()
-
-		s.memCache.Add(id, certFile{now, expCert})
-		actCert, err := s.Get(id, now)
-		assert.NoError(t, err)
-		assert.Equal(t, expCert, *actCert)
-	})
-
 	t.Run("Get a new certificate from the storage (diskCash used)", func(t *testing.T) {
 		s := createTestStorage(t, scheme, basePath)
 
@@ -228,41 +265,33 @@ func Test_VfsStorage_Get(t *testing.T) {
 
 		now := time.Now()
 
-		s.diskCache.Add(id, certLink{now, "/test/test.pdf", "test.pdf"})
+		s.diskCache.Add(id, certLink{timestamp: now, absPath: "/test/test.pdf"})
 		actCert, err := s.Get(id, now)
 		assert.Error(t, err)
 		assert.Nil(t, actCert)
 	})
 
-	t.Run("Replace an old certificate in the memCash", func(t *testing.T) {
+	t.Run("Replace an old certificate in the diskCash", func(t *testing.T) {
 		s := createTestStorage(t, scheme, basePath)
 
-		oldCf := certFile{
-			timestamp: time.Now(),
-			file:      []byte{1, 1, 1, 1},
-		}
-		newCf := certFile{
-			timestamp: oldCf.timestamp.Add(1 * time.Hour),
-			file:      []byte{2, 2, 2, 2},
-		}
+		oldTime := time.Now()
+		oldCert := []byte{1, 1, 1, 1}
+		newTime := oldTime.Add(1 * time.Hour)
+		newCert := []byte{2, 2, 2, 2}
 
-		err := s.Add(id, oldCf.timestamp, &oldCf.file)
+		err := s.Add(id, oldTime, &oldCert)
 		assert.NoError(t, err)
-		actCert, err := s.Get(id, oldCf.timestamp)
+		actCert, err := s.Get(id, oldTime)
 		assert.NoError(t, err)
-		assert.Equal(t, oldCf.file, *actCert)
-		cl1, ok := s.memCache.Peek(id)
-		assert.True(t, ok)
-		assert.Equal(t, oldCf, *cl1)
+		assert.Equal(t, oldCert, *actCert)
 
-		err = s.Add(id, newCf.timestamp, &newCf.file)
+		err = s.Add(id, newTime, &newCert)
 		assert.NoError(t, err)
-		actCert, err = s.Get(id, newCf.timestamp)
+		actCert, err = s.Get(id, newTime)
 		assert.NoError(t, err)
-		assert.Equal(t, newCf.file, *actCert)
-		cl2, ok := s.memCache.Peek(id)
-		assert.True(t, ok)
-		assert.Equal(t, newCf, *cl2)
+		assert.Equal(t, newCert, *actCert)
+		_, err = s.Get(id, newTime.Add(1*time.Hour))
+		assert.Error(t, err)
 	})
 
 	t.Run("vfs return errors", func(t *testing.T) {
@@ -302,6 +331,70 @@ func Test_VfsStorage_Get(t *testing.T) {
 	})
 }
 
+func Test_VfsStorage_Open(t *testing.T) {
+	ts := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+	cert := []byte{1, 2, 3}
+	name := blobName(checksum(cert), FormatPDF)
+
+	t.Run("Open a certificate missing in the storage", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testopen1/")
+		r, err := s.Open("id", ts)
+		assert.Nil(t, r)
+		assert.Error(t, err)
+	})
+
+	t.Run("Read certificate after seeking for its size", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testopen2/")
+		assert.NoError(t, s.Add("id", ts, &cert))
+		r, err := s.Open("id", ts)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer r.Close()
+		rs := r.(io.ReadSeeker)
+		size, err := rs.Seek(0, io.SeekEnd)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(cert)), size)
+		_, err = rs.Seek(0, io.SeekStart)
+		assert.NoError(t, err)
+		got, err := io.ReadAll(rs)
+		assert.NoError(t, err)
+		assert.Equal(t, cert, got)
+	})
+
+	t.Run("Corrupted file fails at the end", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testopen3/")
+		assert.NoError(t, s.Add("id", ts, &cert))
+		writeTestFile(t, s, name, []byte{3, 2, 1})
+		r, err := s.Open("id", ts)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = io.ReadAll(r)
+		assert.ErrorContains(t, err, "checksum mismatch")
+		assert.NoError(t, r.Close())
+		assert.False(t, s.Contains("id", ts))
+		assert.False(t, fileExists(t, s, name))
+	})
+
+	t.Run("Range read isn't verified", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testopen4/")
+		assert.NoError(t, s.Add("id", ts, &cert))
+		writeTestFile(t, s, name, []byte{3, 2, 1})
+		r, err := s.Open("id", ts)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer r.Close()
+		_, err = r.(io.Seeker).Seek(1, io.SeekStart)
+		assert.NoError(t, err)
+		got, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{2, 1}, got)
+		assert.True(t, s.Contains("id", ts))
+	})
+}
+
 func Test_VfsStorage_Contains(t *testing.T) {
 	scheme := mem.Scheme
 	basePath := "/test/"
@@ -315,15 +408,6 @@ func Test_VfsStorage_Contains(t *testing.T) {
 		assert.False(t, exist)
 	})
 
-	t.Run("Check if data with the given id exists (memCash used)", func(t *testing.T) {
-		s := createTestStorage(t, scheme, basePath)
-		now := time.Now()
-
-		s.memCache.Add(id, certFile{now, []byte{}})
-		exist := s.Contains(id, now)
-		assert.True(t, exist)
-	})
-
 	t.Run("Check if data with the given id exists (diskCash used / equal dates)", func(t *testing.T) {
 		s := createTestStorage(t, scheme, basePath)
 		now := time.Now()
@@ -360,21 +444,6 @@ func Test_VfsStorage_Delete(t *testing.T) {
 	basePath := "/test/"
 	id := "id"
 
-	t.Run("Delete a new certificate from the storage (memCache storage)", func(t *testing.T) {
-		s := createTestStorage(t, scheme, basePath)
-		now := time.Now()
-		expCert := []byte{1, 1, 1, 1}
-
-		s.memCache.Add(id, certFile{now, expCert})
-		actCert, err := s.Get(id, now)
-		assert.NoError(t, err)
-		assert.Equal(t, expCert, *actCert)
-
-		s.Delete(id, now)
-		exist := s.Contains(id, now)
-		assert.False(t, exist)
-	})
-
 	t.Run("Delete a new certificate from the storage (diskCache storage)", func(t *testing.T) {
 		s := createTestStorage(t, scheme, basePath)
 		now := time.Now()
@@ -422,6 +491,21 @@ func Test_VfsStorage_Delete(t *testing.T) {
 	})
 }
 
+func Test_VfsStorage_Checksum(t *testing.T) {
+	s := createTestStorage(t, mem.Scheme, "/testchecksum2/")
+	cert := []byte{1, 1, 1, 1}
+	now := time.Now()
+	_, ok := s.Checksum("id", now)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Add("id", now, &cert))
+	sum, ok := s.Checksum("id", now)
+	assert.True(t, ok)
+	assert.Equal(t, checksum(cert), sum)
+	_, ok = s.Checksum("id", now.Add(time.Hour))
+	assert.False(t, ok)
+}
+
 func Test_VfsStorage_Load(t *testing.T) {
 	scheme := mem.Scheme
 	basePath1 := "/test1/"
@@ -566,3 +650,219 @@ func Test_VfsStorage_Load(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+func Test_VfsStorage_CheckHealth(t *testing.T) {
+	basePath := "/testhealth/"
+	t.Run("Writable backend", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, basePath)
+		assert.NoError(t, s.CheckHealth(context.Background()))
+
+		// Probe is removed and doesn't interfere with certificates
+		f, err := s.fs.NewFile(s.volume, basePath+".health/probe")
+		if assert.NoError(t, err) {
+			exists, _ := f.Exists()
+			assert.False(t, exists)
+		}
+		assert.NoError(t, s.Load())
+	})
+	t.Run("Backend fails to create file", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, basePath)
+		mockFs := new(mocks.FileSystem)
+		mockFs.On("NewFile", "", basePath+".health/probe").Return(nil, fmt.Errorf("read-only"))
+		s.fs = mockFs
+		assert.ErrorContains(t, s.CheckHealth(context.Background()), "read-only")
+	})
+}
+
+func Test_parseCertFileName(t *testing.T) {
+	ts := time.Date(2022, 12, 16, 15, 25, 14, 59361000, time.UTC)
+	tData := map[string]struct {
+		name string
+		id   string
+		ok   bool
+	}{
+		"Legacy id":               {"06e8469f_2022-12-16 15:25:14.059361 +0000 UTC.pdf", "06e8469f", true},
+		"Human id":                {"GUS-2022-7K3F-N2QC-ZB7A-9_2022-12-16 15:25:14.059361 +0000 UTC.pdf", "GUS-2022-7K3F-N2QC-ZB7A-9", true},
+		"Monotonic clock reading": {"06e8469f_2022-12-16 15:25:14.059361 +0000 UTC m=+0.001.pdf", "06e8469f", true},
+		"Not pdf":                 {"06e8469f_2022-12-16 15:25:14.059361 +0000 UTC.txt", "", false},
+		"No timestamp":            {"06e8469f.pdf", "", false},
+		"Malformed timestamp":     {"06e8469f_yesterday.pdf", "", false},
+		"Empty id":                {"_2022-12-16 15:25:14.059361 +0000 UTC.pdf", "", false},
+	}
+	for name, d := range tData {
+		d := d
+		t.Run(name, func(t *testing.T) {
+			id, got, ok := parseCertFileName(d.name)
+			assert.Equal(t, d.ok, ok)
+			assert.Equal(t, d.id, id)
+			if d.ok {
+				assert.True(t, ts.Equal(got))
+			}
+		})
+	}
+}
+
+func Test_blobSum(t *testing.T) {
+	sum := checksum([]byte{1})
+	for name, exp := range map[string]bool{
+		sum + ".pdf":                  true,
+		sum + ".png":                  true,
+		sum + ".jpeg":                 true,
+		sum + ".txt":                  false,
+		sum:                           false,
+		strings.ToUpper(sum) + ".pdf": false,
+		"06e8469f.pdf":                false,
+		storageIndexName:              false,
+	} {
+		got, ok := blobSum(name)
+		assert.Equal(t, exp, ok, name)
+		if exp {
+			assert.Equal(t, sum, got)
+		}
+	}
+}
+
+func Test_VfsStorage_Load_SkipsForeignFiles(t *testing.T) {
+	s := createTestStorage(t, mem.Scheme, "/testforeign/")
+	for _, n := range []string{
+		"06e8469f_2022-12-16 15:25:14.059361 +0000 UTC.pdf",
+		"README.txt",
+		"01GMFQ8V6Q3WJ7F3Q4K0Y2G9ZC_2022-12-16 15:25:14.079859 +0000 UTC.pdf",
+	} {
+		file, err := s.fs.NewFile(s.volume, s.basePath+n)
+		if err != nil {
+			assert.FailNow(t, "unexpected error: %v", err)
+		}
+		if _, err = file.Write([]byte{}); err != nil {
+			assert.FailNow(t, "unexpected error: %v", err)
+		}
+		if err = file.Close(); err != nil {
+			assert.FailNow(t, "unexpected error: %v", err)
+		}
+	}
+	assert.NoError(t, s.Load())
+	assert.ElementsMatch(t, []string{"06e8469f", "01GMFQ8V6Q3WJ7F3Q4K0Y2G9ZC"}, s.diskCache.Keys())
+}
+
+type testS3Client struct {
+	s3iface.S3API
+	pages  []*awss3.ListObjectsV2Output
+	inputs []*awss3.ListObjectsV2Input
+}
+
+func (c *testS3Client) ListObjectsV2Pages(input *awss3.ListObjectsV2Input, fn func(*awss3.ListObjectsV2Output, bool) bool) error {
+	c.inputs = append(c.inputs, input)
+	for i, p := range c.pages {
+		if !fn(p, i == len(c.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func Test_VfsStorage_list_S3(t *testing.T) {
+	client := &testS3Client{pages: []*awss3.ListObjectsV2Output{
+		{Contents: []*awss3.Object{{Key: aws.String("certs/")}, {Key: aws.String("certs/06e8469f_2022-12-16 15:25:14.059361 +0000 UTC.pdf")}}},
+		{Contents: []*awss3.Object{{Key: aws.String("certs/10af7531_2022-12-16 15:25:14.079859 +0000 UTC.pdf")}}},
+	}}
+	s, err := NewVfsStorage("bucket", "/certs/", s3.Scheme, nil, 0)
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	s.fs = s.fs.(*s3.FileSystem).WithClient(client)
+
+	loc, err := s.fs.NewLocation("bucket", "/certs/")
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	names, err := s.list(loc)
+	assert.NoError(t, err)
+	if assert.Len(t, client.inputs, 1) {
+		assert.Equal(t, "bucket", aws.StringValue(client.inputs[0].Bucket))
+		assert.Equal(t, "certs/", aws.StringValue(client.inputs[0].Prefix))
+		assert.Equal(t, "/", aws.StringValue(client.inputs[0].Delimiter))
+	}
+	// Keys of both pages are listed
+	assert.Equal(t, []string{"06e8469f_2022-12-16 15:25:14.059361 +0000 UTC.pdf", "10af7531_2022-12-16 15:25:14.079859 +0000 UTC.pdf"}, names)
+}
+
+func Test_InitBackend_S3(t *testing.T) {
+	var opts vfs.Options = s3.Options{Region: "eu-central-1"}
+	fs1, err := InitBackend(s3.Scheme, &opts)
+	assert.NoError(t, err)
+	fs2, err := InitBackend(s3.Scheme, nil)
+	assert.NoError(t, err)
+	// Backends with different options don't share registered instance
+	assert.NotSame(t, fs1, fs2)
+}
+
+func Test_VfsStorage_onLinkEviction(t *testing.T) {
+	basePath := "/testeviction/"
+	s, err := NewVfsStorage("", basePath, mem.Scheme, nil, 1)
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	calls := 0
+	s.fs = fsCountingCalls{s.fs, &calls}
+	now := time.Now()
+
+	assert.NoError(t, s.Add("first", now, &[]byte{1}))
+	first, _ := s.diskCache.Peek("first")
+	firstPath := first.absPath
+	assert.NoError(t, s.Add("second", now, &[]byte{2}))
+
+	// Evicted file is deleted through storage backend
+	assert.Equal(t, 3, calls)
+	f, err := s.fs.NewFile(s.volume, firstPath)
+	if assert.NoError(t, err) {
+		exists, _ := f.Exists()
+		assert.False(t, exists)
+	}
+}
+
+func Test_InitBackend(t *testing.T) {
+	options := func(o vfs.Options) *vfs.Options { return &o }
+	t.Run("gs", func(t *testing.T) {
+		fs, err := InitBackend(gs.Scheme, options(gs.Options{APIKey: "key", Endpoint: "http://localhost:4443/storage/v1/"}))
+		assert.NoError(t, err)
+		assert.IsType(t, &gs.FileSystem{}, fs)
+	})
+	t.Run("azure with endpoint", func(t *testing.T) {
+		fs, err := InitBackend(AzureScheme, options(AzureOptions{
+			Options:  azure.Options{AccountName: "devstoreaccount1", AccountKey: "a2V5"},
+			Endpoint: "http://127.0.0.1:10000/devstoreaccount1",
+		}))
+		if assert.NoError(t, err) {
+			client, _ := fs.(*azure.FileSystem).Client()
+			assert.IsType(t, &azureEndpointClient{}, client)
+		}
+	})
+	t.Run("azure with relative endpoint", func(t *testing.T) {
+		_, err := InitBackend(AzureScheme, options(AzureOptions{Endpoint: "devstoreaccount1"}))
+		assert.ErrorContains(t, err, "endpoint must be absolute URL")
+	})
+	t.Run("sftp", func(t *testing.T) {
+		fs, err := InitBackend(sftp.Scheme, options(sftp.Options{Password: "secret"}))
+		assert.NoError(t, err)
+		assert.IsType(t, &sftp.FileSystem{}, fs)
+	})
+	t.Run("Options of another backend", func(t *testing.T) {
+		_, err := InitBackend(gs.Scheme, options(s3.Options{}))
+		assert.ErrorContains(t, err, "unexpected options: s3.Options")
+	})
+}
+
+func Test_azureEndpointClient_blobURL(t *testing.T) {
+	client, err := newAzureEndpointClient(&azure.Options{AccountName: "devstoreaccount1", AccountKey: "a2V5"},
+		"http://127.0.0.1:10000/devstoreaccount1")
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	fs := azure.NewFileSystem().WithClient(client)
+	f, err := fs.NewFile("certs", "/generated/06e8469f.pdf")
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	u := client.blobURL(f).URL()
+	assert.Equal(t, "http://127.0.0.1:10000/devstoreaccount1/certs/generated/06e8469f.pdf", u.String())
+}
@@ -0,0 +1,125 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/c2fo/vfs/v6"
+)
+
+// Name of index file kept in basePath of VfsStorage, it isn't named like certificate files, so Load never takes it for one
+const storageIndexName = ".index.json"
+
+const storageIndexVersion = 1
+
+// Index of certificate files, persisting state of VfsStorage across restarts
+type storageIndex struct {
+	Version int `json:"version"`
+	// From least to most recently used
+	Entries []storageIndexEntry `json:"entries"`
+}
+
+type storageIndexEntry struct {
+	Id        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	// Name of file relative to basePath
+	File       string    `json:"file"`
+	Size       int       `json:"size"`
+	Checksum   string    `json:"checksum,omitempty"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+// Writes index of certificates in diskCache, so Load restores their files and LRU order.
+// Files missing from index aren't deleted by Load, so certificates added since index was saved only lose their cache entries.
+func (s *VfsStorage) SaveIndex() error {
+	index := storageIndex{Version: storageIndexVersion, Entries: []storageIndexEntry{}}
+	for _, id := range s.diskCache.Keys() {
+		cl, ok := s.diskCache.Peek(id)
+		if !ok || !strings.HasPrefix(cl.absPath, s.basePath) {
+			continue
+		}
+		index.Entries = append(index.Entries, storageIndexEntry{
+			Id:        id,
+			Timestamp: cl.timestamp,
+			File:      strings.TrimPrefix(cl.absPath, s.basePath),
+			Size:      cl.size,
+			Checksum:  cl.checksum,
+		})
+	}
+	// Eviction takes accessMu under lock of diskCache, so it isn't held while diskCache is read
+	s.accessMu.Lock()
+	for i := range index.Entries {
+		index.Entries[i].LastAccess = s.accessed[index.Entries[i].Id]
+	}
+	s.accessMu.Unlock()
+
+	b, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+	file, err := s.fs.NewFile(s.volume, s.basePath+storageIndexName)
+	if err != nil {
+		return fmt.Errorf("failed to initialize index file: %w", err)
+	}
+	if _, err = file.Write(b); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close index file: %w", err)
+	}
+	return nil
+}
+
+// Entries of index, none when index doesn't exist. Malformed index is ignored, so Load falls back to file names.
+func (s *VfsStorage) readIndex(loc vfs.Location) ([]storageIndexEntry, error) {
+	file, err := loc.NewFile(storageIndexName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize index file: %w", err)
+	}
+	exists, err := file.Exists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check index file: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	b, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close index file: %w", err)
+	}
+	var index storageIndex
+	if err = json.Unmarshal(b, &index); err != nil || index.Version != storageIndexVersion {
+		log.Printf("Ignoring malformed storage index %s: version %d, %v", file.Path(), index.Version, err)
+		return nil, nil
+	}
+	return index.Entries, nil
+}
+
+// Storage persisting its state across restarts
+type IndexSaver interface {
+	SaveIndex() error
+}
+
+// Saves index of s every interval until ctx is done
+func RunIndexSaver(ctx context.Context, s IndexSaver, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SaveIndex(); err != nil {
+				log.Printf("Failed to save storage index: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/c2fo/vfs/v6/backend/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, s *VfsStorage, name string, content []byte) {
+	file, err := s.fs.NewFile(s.volume, s.basePath+name)
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	if _, err = file.Write(content); err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	if err = file.Close(); err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+}
+
+func fileExists(t *testing.T, s *VfsStorage, name string) bool {
+	file, err := s.fs.NewFile(s.volume, s.basePath+name)
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	exists, err := file.Exists()
+	if err != nil {
+		assert.FailNow(t, "unexpected error: %v", err)
+	}
+	return exists
+}
+
+func Test_VfsStorage_SaveIndex(t *testing.T) {
+	basePath := "/testindex1/"
+	ts := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+	s := createTestStorage(t, mem.Scheme, basePath)
+	now := ts
+	s.now = func() time.Time { return now }
+	for _, id := range []string{"06e8469f", "10af7531", "617dfc5c"} {
+		now = now.Add(time.Minute)
+		assert.NoError(t, s.Add(id, ts, &[]byte{1, 2, 3}))
+	}
+	// Makes 06e8469f the most recently used
+	now = now.Add(time.Minute)
+	_, err := s.Get("06e8469f", ts)
+	assert.NoError(t, err)
+	assert.NoError(t, s.SaveIndex())
+
+	t.Run("Restart restores LRU order and files of certificates", func(t *testing.T) {
+		s2 := createTestStorage(t, mem.Scheme, basePath)
+		assert.NoError(t, s2.Load())
+		assert.Equal(t, []string{"10af7531", "617dfc5c", "06e8469f"}, s2.diskCache.Keys())
+		cl, ok := s2.diskCache.Peek("10af7531")
+		if assert.True(t, ok) {
+			assert.Equal(t, 3, cl.size)
+			assert.Equal(t, checksum([]byte{1, 2, 3}), cl.checksum)
+		}
+		assert.Equal(t, ts.Add(4*time.Minute), s2.accessed["06e8469f"])
+		assert.Equal(t, 3, s2.refs[checksum([]byte{1, 2, 3})])
+	})
+	t.Run("Unindexed files are moved or kept", func(t *testing.T) {
+		legacy := "fac0a04c_2022-12-16 15:25:14 +0000 UTC.pdf"
+		orphan := blobName(checksum([]byte{4}), FormatPDF)
+		writeTestFile(t, s, legacy, []byte{5})
+		writeTestFile(t, s, orphan, []byte{4})
+		writeTestFile(t, s, ".DS_Store", []byte{})
+
+		s2 := createTestStorage(t, mem.Scheme, basePath)
+		assert.NoError(t, s2.Load())
+		// Files written before content addressing are least recently used
+		assert.Equal(t, []string{"fac0a04c", "10af7531", "617dfc5c", "06e8469f"}, s2.diskCache.Keys())
+		cl, _ := s2.diskCache.Peek("fac0a04c")
+		assert.Equal(t, checksum([]byte{5}), cl.checksum)
+		assert.True(t, fileExists(t, s2, blobName(checksum([]byte{5}), FormatPDF)))
+		assert.False(t, fileExists(t, s2, legacy))
+		// File may be referenced by another instance or written after index was saved
+		assert.True(t, fileExists(t, s2, orphan))
+		assert.True(t, fileExists(t, s2, ".DS_Store"))
+		assert.NoError(t, s2.SaveIndex())
+	})
+	t.Run("Certificates of missing files are skipped", func(t *testing.T) {
+		f, _ := s.fs.NewFile(s.volume, basePath+blobName(checksum([]byte{1, 2, 3}), FormatPDF))
+		assert.NoError(t, f.Delete())
+
+		s2 := createTestStorage(t, mem.Scheme, basePath)
+		assert.NoError(t, s2.Load())
+		assert.Equal(t, []string{"fac0a04c"}, s2.diskCache.Keys())
+	})
+}
+
+func Test_VfsStorage_Load_Index(t *testing.T) {
+	ts := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+	t.Run("Malformed index is ignored", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testindex2/")
+		writeTestFile(t, s, storageIndexName, []byte("{"))
+		writeTestFile(t, s, "06e8469f_2022-12-16 15:25:14 +0000 UTC.pdf", []byte{})
+		assert.NoError(t, s.Load())
+		assert.Equal(t, []string{"06e8469f"}, s.diskCache.Keys())
+	})
+	t.Run("Newer file than indexed one replaces it", func(t *testing.T) {
+		s := createTestStorage(t, mem.Scheme, "/testindex3/")
+		assert.NoError(t, s.Add("06e8469f", ts, &[]byte{1}))
+		assert.NoError(t, s.SaveIndex())
+		newer := "06e8469f_" + ts.Add(time.Hour).String() + ".pdf"
+		writeTestFile(t, s, newer, []byte{2})
+
+		s2 := createTestStorage(t, mem.Scheme, "/testindex3/")
+		assert.NoError(t, s2.Load())
+		assert.True(t, s2.Contains("06e8469f", ts.Add(time.Hour)))
+		// Content of outdated certificate isn't referenced here, but it is kept like any unindexed file
+		assert.True(t, fileExists(t, s2, blobName(checksum([]byte{1}), FormatPDF)))
+		assert.True(t, fileExists(t, s2, blobName(checksum([]byte{2}), FormatPDF)))
+	})
+}
+
+func Test_VfsStorage_Get_Checksum(t *testing.T) {
+	s := createTestStorage(t, mem.Scheme, "/testchecksum/")
+	ts := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+	name := blobName(checksum([]byte{1, 2, 3}), FormatPDF)
+	assert.NoError(t, s.Add("06e8469f", ts, &[]byte{1, 2, 3}))
+	writeTestFile(t, s, name, []byte{3, 2, 1})
+
+	_, err := s.Get("06e8469f", ts)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	// Corrupted file isn't referenced anymore
+	assert.False(t, s.Contains("06e8469f", ts))
+	assert.False(t, fileExists(t, s, name))
+}
+
+type countingIndexSaver struct {
+	n atomic.Int32
+}
+
+func (s *countingIndexSaver) SaveIndex() error {
+	s.n.Add(1)
+	return fmt.Errorf("SaveIndex error")
+}
+
+func Test_RunIndexSaver(t *testing.T) {
+	s := &countingIndexSaver{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		RunIndexSaver(ctx, s, time.Millisecond)
+		close(done)
+	}()
+	// Failed save doesn't stop saver
+	assert.Eventually(t, func() bool { return s.n.Load() >= 2 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
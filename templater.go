@@ -2,22 +2,62 @@ package golangunitedschoolcerts
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	tmpl "html/template"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"time"
 
 	qrcode "github.com/skip2/go-qrcode"
 )
 
 type Templater interface {
-	GenerateCertificate(template string, certificate *Certificate, link string) (*[]byte, error)
+	GenerateCertificate(template string, certificate *Certificate, link string, variant Variant) (*[]byte, error)
 }
 
 type GotenbergTemplater struct {
-	url string
+	url    string
+	client *http.Client
+	// Size of full size images in pixels, thumbnails keep its aspect ratio
+	imageWidth, imageHeight int
+	pdf                     PDFOptions
+}
+
+// Metadata and archival conformance of generated PDF files, see GotenbergTemplater.SetPDFOptions
+type PDFOptions struct {
+	// Author of documents, organization issuing certificates
+	Author string
+	// PDF/A conformance of documents: "PDF/A-1b", "PDF/A-2b" or "PDF/A-3b", empty means none
+	PDFA string
+	// Embed certificate data as JSON attachment, only PDF/A-3 allows it among PDF/A levels
+	AttachData bool
+	// Base URL of verification links in attachment, the same as of certificate links
+	PublicURL string
+}
+
+// PDF/A levels gotenberg converts to
+var PDFALevels = []string{"PDF/A-1b", "PDF/A-2b", "PDF/A-3b"}
+
+// Name of certificate data attachment of PDF files
+const certificateAttachmentName = "certificate.json"
+
+// Certificate data attached to PDF files, machine-readable copy of what is printed
+type certificateAttachment struct {
+	Id              string     `json:"id"`
+	Student         string     `json:"student"`
+	IssueDate       string     `json:"issueDate"`
+	Course          string     `json:"course"`
+	Mentors         string     `json:"mentors"`
+	ValidFrom       *time.Time `json:"validFrom,omitempty"`
+	ValidUntil      *time.Time `json:"validUntil,omitempty"`
+	Link            string     `json:"link"`
+	VerificationURL string     `json:"verificationUrl"`
 }
 
 // Data structure for html template
@@ -27,27 +67,63 @@ type data struct {
 	Qr   string
 }
 
-func NewGotenbergTemplater(url string) *GotenbergTemplater {
-	return &GotenbergTemplater{url}
+// Zero timeout means gotenberg requests never time out. Images are screenshots of imageWidth x imageHeight pixels,
+// which should match @page size of templates.
+func NewGotenbergTemplater(url string, timeout time.Duration, imageWidth, imageHeight int) *GotenbergTemplater {
+	return &GotenbergTemplater{url: url, client: &http.Client{Timeout: timeout}, imageWidth: imageWidth, imageHeight: imageHeight}
 }
 
-// Generate QR code with needed recovery level, size in pxs and base64 encoding
-func linkToQR(link string, rcL qrcode.RecoveryLevel, size int) (string, error) {
+// PDF files are generated with metadata and conformance of options
+func (g *GotenbergTemplater) SetPDFOptions(o PDFOptions) {
+	g.pdf = o
+}
 
-	// Encode link to QR code
-	png, err := qrcode.Encode(link, rcL, size)
+// Checks that gotenberg reports itself healthy
+func (g *GotenbergTemplater) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url+"/health", nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode QR code: %w", err)
+		return err
 	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to perform GET request to gotenberg: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gotenberg is unhealthy: %v", resp.Status)
+	}
+	return nil
+}
 
+// Generate base64 PNG of QR code with needed recovery level and size in pxs, other options are defaults of qr function
+func linkToQR(link string, rcL qrcode.RecoveryLevel, size int) (string, error) {
+	o := defaultQROptions()
+	o.level = rcL
+	png, err := renderQR(link, size, o)
+	if err != nil {
+		return "", err
+	}
 	return base64.StdEncoding.EncodeToString(png), nil
 }
 
+// Functions available in HTML templates
+var templateFuncs = tmpl.FuncMap{
+	// Formats optional time with Go layout, e.g. {{ date .Cert.ValidUntil "2 January 2006" }}, nil is rendered empty
+	"date": func(t *time.Time, layout string) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	},
+	// Renders QR code as data URI, e.g. <img src="{{ qr .Link 256 "format" "svg" }}">, see qrFunc for options
+	"qr": qrFunc,
+}
+
 // Execute html template with given data
 func renderHTML(template string, d *data) (*[]byte, error) {
 
 	// Creating HTML template and checking for correct parsing
-	t, err := tmpl.New("HTML").Parse(template)
+	t, err := tmpl.New("HTML").Funcs(templateFuncs).Parse(template)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML template: %w", err)
 	}
@@ -63,7 +139,68 @@ func renderHTML(template string, d *data) (*[]byte, error) {
 	return &sl, nil
 }
 
-func (g *GotenbergTemplater) renderPDF(html *[]byte) (*[]byte, error) {
+// Renders PDF file with document properties of certificate, optionally converted to PDF/A and carrying its data as attachment
+func (g *GotenbergTemplater) renderPDF(html *[]byte, cert *Certificate, link string) (*[]byte, error) {
+	metadata, err := json.Marshal(map[string]any{
+		"Title":        cert.Course + " - " + cert.Student,
+		"Author":       g.pdf.Author,
+		"Subject":      cert.Course,
+		"Keywords":     []string{"certificate", cert.Course, cert.Id},
+		"Identifier":   cert.Id,
+		"CreationDate": cert.Timestamp.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PDF metadata: %w", err)
+	}
+	fields := map[string]string{
+		// respect @page properties stated in css
+		"preferCssPageSize": "true",
+		"metadata":          string(metadata),
+	}
+	if g.pdf.PDFA != "" {
+		fields["pdfa"] = g.pdf.PDFA
+	}
+	var embeds map[string][]byte
+	if g.pdf.AttachData {
+		data, err := json.Marshal(certificateAttachment{
+			Id:              cert.Id,
+			Student:         cert.Student,
+			IssueDate:       cert.IssueDate,
+			Course:          cert.Course,
+			Mentors:         cert.Mentors,
+			ValidFrom:       cert.ValidFrom,
+			ValidUntil:      cert.ValidUntil,
+			Link:            link,
+			VerificationURL: g.pdf.PublicURL + "certificate/" + cert.Id + "/verify",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal certificate attachment: %w", err)
+		}
+		embeds = map[string][]byte{certificateAttachmentName: data}
+	}
+	return g.convert("/forms/chromium/convert/html", *html, fields, embeds)
+}
+
+// Takes screenshot of page clipped to image size. Thumbnails zoom page out instead of downscaling screenshot,
+// so Chromium renders them sharp.
+func (g *GotenbergTemplater) renderImage(html *[]byte, v Variant) (*[]byte, error) {
+	page, width, height := *html, g.imageWidth, g.imageHeight
+	if v.Width != 0 {
+		zoom := float64(v.Width) / float64(g.imageWidth)
+		width, height = v.Width, int(math.Round(float64(g.imageHeight)*zoom))
+		// Style after the end of document is moved into body by parser and still applies to whole page
+		page = append(append([]byte{}, page...), fmt.Sprintf("<style>html{zoom:%g}</style>", zoom)...)
+	}
+	return g.convert("/forms/chromium/screenshot/html", page, map[string]string{
+		"format": string(v.Format),
+		"width":  strconv.Itoa(width),
+		"height": strconv.Itoa(height),
+		"clip":   "true",
+	}, nil)
+}
+
+// Posts page with form fields and files embedded into PDF to gotenberg route, returning converted file
+func (g *GotenbergTemplater) convert(route string, html []byte, fields map[string]string, embeds map[string][]byte) (*[]byte, error) {
 	buf := &bytes.Buffer{}
 	writer := multipart.NewWriter(buf)
 
@@ -72,38 +209,50 @@ func (g *GotenbergTemplater) renderPDF(html *[]byte) (*[]byte, error) {
 		return nil, fmt.Errorf("failed to crete form file: %w", err)
 	}
 
-	_, err = part.Write(*html)
+	_, err = part.Write(html)
 	if err != nil {
 		return nil, fmt.Errorf("failed to write template to multipart: %w", err)
 	}
 
-	// respect @page properties stated in css
-	err = writer.WriteField("preferCssPageSize", "true")
-	if err != nil {
-		return nil, fmt.Errorf("failed to write preferCssPageSize to multipart: %w", err)
+	for k, v := range fields {
+		if err = writer.WriteField(k, v); err != nil {
+			return nil, fmt.Errorf("failed to write %s to multipart: %w", k, err)
+		}
+	}
+
+	for name, b := range embeds {
+		part, err := writer.CreateFormFile("embeds", name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create embedded file %s: %w", name, err)
+		}
+		if _, err = part.Write(b); err != nil {
+			return nil, fmt.Errorf("failed to write embedded file %s to multipart: %w", name, err)
+		}
 	}
 
 	writer.Close()
 
-	resp, err := http.Post(g.url+"/forms/chromium/convert/html", writer.FormDataContentType(), buf)
+	resp, err := g.client.Post(g.url+route, writer.FormDataContentType(), buf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform POST request to gotenber: %w", err)
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("gotenberg return error: %v", resp.Status)
 	}
 
-	pdf, err := io.ReadAll(resp.Body)
+	file, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read gotenber response: %w", err)
 	}
-	return &pdf, nil
+	return &file, nil
 }
 
-func (g *GotenbergTemplater) GenerateCertificate(template string, cert *Certificate, link string) (*[]byte, error) {
-	// -4 makes each QR "pixel" to be 4px in size
-	qr, err := linkToQR(link, qrcode.High, -4)
+// Renders certificate in format of variant
+func (g *GotenbergTemplater) GenerateCertificate(template string, cert *Certificate, link string, v Variant) (*[]byte, error) {
+	// -4 makes each QR "pixel" to be 4px in size, level is default of qr function
+	qr, err := linkToQR(link, defaultQROptions().level, -4)
 	if err != nil {
 		return nil, err
 	}
@@ -116,9 +265,8 @@ func (g *GotenbergTemplater) GenerateCertificate(template string, cert *Certific
 	if err != nil {
 		return nil, err
 	}
-	pdf, err := g.renderPDF(html)
-	if err != nil {
-		return nil, err
+	if v.Format == FormatPDF {
+		return g.renderPDF(html, cert, link)
 	}
-	return pdf, nil
+	return g.renderImage(html, v)
 }
@@ -2,12 +2,15 @@ package golangunitedschoolcerts
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	qrcode "github.com/skip2/go-qrcode"
 	"github.com/stretchr/testify/assert"
@@ -99,6 +102,9 @@ func Test_renderHTML(t *testing.T) {
 		t.Errorf("Can't load expected result file expindex.html")
 	}
 
+	validFrom := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	tData := map[string]struct {
 		tmpl    string
 		d       data
@@ -119,6 +125,22 @@ func Test_renderHTML(t *testing.T) {
 			},
 			[]byte(eFile),
 			nil},
+		"validity window": {
+			`{{ with .Cert.ValidUntil }}Valid {{ date $.Cert.ValidFrom "2006-01-02" }} - {{ date . "2006-01-02" }}{{ end }}`,
+			data{
+				Certificate{
+					ValidFrom:  &validFrom,
+					ValidUntil: &validUntil,
+				},
+				"", "",
+			},
+			[]byte("Valid 2022-01-01 - 2024-01-01"),
+			nil},
+		"no validity window": {
+			`{{ with .Cert.ValidUntil }}Valid until {{ date . "2006-01-02" }}{{ end }}{{ date .Cert.ValidFrom "2006" }}`,
+			data{Certificate{}, "", ""},
+			[]byte(nil),
+			nil},
 	}
 
 	for name, tcase := range tData {
@@ -141,15 +163,130 @@ func Test_renderHTML(t *testing.T) {
 func Test_renderPDF(t *testing.T) {
 	html := &[]byte{}
 	exp := &[]byte{0, 1, 0, 1}
+	validUntil := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := &Certificate{
+		Id:         "12345678",
+		Timestamp:  time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC),
+		Student:    "Test Student",
+		IssueDate:  "1 December 2022",
+		Course:     "Test Course",
+		Mentors:    "Test Mentor",
+		ValidUntil: &validUntil,
+	}
+	link := host + "certificate/" + cert.Id
 
 	url, closer := mockGotenbergService(t, *exp)
 	defer closer()
 
-	g := NewGotenbergTemplater(url)
-	got, err := g.renderPDF(html)
+	g := NewGotenbergTemplater(url, 0, 1123, 794)
+	got, err := g.renderPDF(html, cert, link)
 
 	assert.NoError(t, err)
 	assert.Equal(t, exp, got)
+
+	t.Run("Document properties, PDF/A and data attachment", func(t *testing.T) {
+		mock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/forms/chromium/convert/html", req.URL.Path)
+			assert.Equal(t, "true", req.FormValue("preferCssPageSize"))
+			assert.Equal(t, "PDF/A-3b", req.FormValue("pdfa"))
+			assert.JSONEq(t, `{
+				"Title": "Test Course - Test Student",
+				"Author": "Test School",
+				"Subject": "Test Course",
+				"Keywords": ["certificate", "Test Course", "12345678"],
+				"Identifier": "12345678",
+				"CreationDate": "2022-12-16T15:25:14Z"
+			}`, req.FormValue("metadata"))
+			f, h, err := req.FormFile("embeds")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "certificate.json", h.Filename)
+				data, _ := io.ReadAll(f)
+				assert.JSONEq(t, `{
+					"id": "12345678",
+					"student": "Test Student",
+					"issueDate": "1 December 2022",
+					"course": "Test Course",
+					"mentors": "Test Mentor",
+					"validUntil": "2025-06-01T00:00:00Z",
+					"link": "`+link+`",
+					"verificationUrl": "`+host+`certificate/12345678/verify"
+				}`, string(data))
+			}
+			rw.Write(*exp)
+		}))
+		defer mock.Close()
+
+		g := NewGotenbergTemplater(mock.URL, 0, 1123, 794)
+		g.SetPDFOptions(PDFOptions{Author: "Test School", PDFA: "PDF/A-3b", AttachData: true, PublicURL: host})
+		got, err := g.renderPDF(html, cert, link)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, got)
+	})
+
+	t.Run("Nothing attached by default", func(t *testing.T) {
+		mock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.NoError(t, req.ParseMultipartForm(1<<20))
+			assert.Empty(t, req.MultipartForm.File["embeds"])
+			assert.NotContains(t, req.MultipartForm.Value, "pdfa")
+			rw.Write(*exp)
+		}))
+		defer mock.Close()
+
+		_, err := NewGotenbergTemplater(mock.URL, 0, 1123, 794).renderPDF(html, cert, link)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Request times out", func(t *testing.T) {
+		done := make(chan struct{})
+		mock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			<-done
+		}))
+		defer mock.Close()
+		defer close(done)
+
+		g := NewGotenbergTemplater(mock.URL, 10*time.Millisecond, 1123, 794)
+		_, err := g.renderPDF(html, cert, link)
+		assert.ErrorContains(t, err, "Client.Timeout exceeded")
+	})
+}
+
+func Test_renderImage(t *testing.T) {
+	html := []byte("<html><body>cert</body></html>")
+	exp := []byte{0, 1, 0, 1}
+	tests := map[string]struct {
+		v         Variant
+		expWidth  string
+		expHeight string
+		expZoom   string
+	}{
+		"Full size":  {Variant{Format: FormatPNG}, "1600", "1000", ""},
+		"Thumbnail":  {Variant{Format: FormatWEBP, Width: 320}, "320", "200", "<style>html{zoom:0.2}</style>"},
+		"Open Graph": {Variant{Format: FormatJPEG, Width: 1200}, "1200", "750", "<style>html{zoom:0.75}</style>"},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			mock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/forms/chromium/screenshot/html", req.URL.Path)
+				assert.Equal(t, string(tt.v.Format), req.FormValue("format"))
+				assert.Equal(t, tt.expWidth, req.FormValue("width"))
+				assert.Equal(t, tt.expHeight, req.FormValue("height"))
+				assert.Equal(t, "true", req.FormValue("clip"))
+				f, _, err := req.FormFile("files")
+				if assert.NoError(t, err) {
+					page, _ := io.ReadAll(f)
+					assert.Equal(t, string(html)+tt.expZoom, string(page))
+				}
+				rw.Write(exp)
+			}))
+			defer mock.Close()
+
+			g := NewGotenbergTemplater(mock.URL, 0, 1600, 1000)
+			got, err := g.renderImage(&html, tt.v)
+			assert.NoError(t, err)
+			assert.Equal(t, exp, *got)
+		})
+	}
 }
 
 func Test_GenerateCertificate(t *testing.T) {
@@ -175,10 +312,30 @@ func Test_GenerateCertificate(t *testing.T) {
 		url, closer := mockGotenbergService(t, *exp)
 		defer closer()
 
-		g := NewGotenbergTemplater(url)
+		g := NewGotenbergTemplater(url, 0, 1123, 794)
 
-		got, err := g.GenerateCertificate(template, cert, link)
+		got, err := g.GenerateCertificate(template, cert, link, PDF)
 		assert.NoError(t, err)
 		assert.Equal(t, exp, got)
 	})
 }
+
+func Test_GotenbergTemplater_CheckHealth(t *testing.T) {
+	for name, code := range map[string]int{"Healthy": http.StatusOK, "Unhealthy": http.StatusServiceUnavailable} {
+		code := code
+		t.Run(name, func(t *testing.T) {
+			mock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/health", req.URL.Path)
+				rw.WriteHeader(code)
+			}))
+			defer mock.Close()
+
+			err := NewGotenbergTemplater(mock.URL, 0, 1123, 794).CheckHealth(context.Background())
+			if code == http.StatusOK {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "gotenberg is unhealthy: 503")
+			}
+		})
+	}
+}
@@ -182,4 +182,71 @@ func Test_Demo(t *testing.T) {
 	}
 	wg.Wait()
 
+	// Stream PDF files through gRPC, already generated by GetCertificate calls
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			stream, err := client.DownloadCertificate(ctx, &api.DownloadCertificateRequest{Id: id})
+			if !assert.NoError(t, err) {
+				assert.FailNow(t, "failed to download certificate:", err)
+			}
+			size := 0
+			for {
+				chunk, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					assert.FailNow(t, "failed to receive certificate chunk:", err)
+				}
+				size += len(chunk.GetData())
+			}
+			assert.NotZero(t, size)
+			t.Log("Download PDF certificates file through gRPC stream with id:", id)
+		}(id)
+	}
+	wg.Wait()
+
+	// Download beginning of PDF files through REST with range request, generates PDF files
+	for _, id := range restIds {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, httpHost+"/certificate/"+id+"/download", nil)
+			if err != nil {
+				assert.FailNow(t, "failed to create request:", err)
+			}
+			req.Header.Set("Range", "bytes=0-4")
+			resp, err := http.DefaultClient.Do(req)
+			if !assert.NoError(t, err) || !assert.Equal(t, http.StatusPartialContent, resp.StatusCode) {
+				assert.FailNow(t, "failed to download certificate:", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				assert.FailNow(t, "failed to read response body:", err)
+			}
+			assert.Equal(t, "%PDF-", string(body))
+			t.Log("Download PDF certificates file header through REST with id:", id)
+		}(id)
+	}
+	wg.Wait()
+
+	// Open share pages of certificates, which link their images
+	for _, id := range restIds {
+		resp, err := http.Get(httpHost + "/c/" + id)
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+			assert.FailNow(t, "failed to get share page:", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			assert.FailNow(t, "failed to read response body:", err)
+		}
+		assert.Contains(t, string(body), `property="og:image"`)
+		t.Log("Open share page of certificate with id:", id)
+	}
+
 }
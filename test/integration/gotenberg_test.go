@@ -3,8 +3,10 @@
 package integration
 
 import (
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	crt "gitlab.com/DzmitryYafremenka/golang-united-school-certs"
@@ -34,8 +36,8 @@ func Test_Gotenber(t *testing.T) {
 
 	link := "http://example.com/certificates/" + cert.Id
 
-	g := crt.NewGotenbergTemplater("http://localhost:3000")
-	got, err := g.GenerateCertificate(string(template), cert, link)
+	g := crt.NewGotenbergTemplater("http://localhost:3000", time.Minute, 1123, 794)
+	got, err := g.GenerateCertificate(string(template), cert, link, crt.PDF)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, got)
@@ -58,4 +60,11 @@ func Test_Gotenber(t *testing.T) {
 	if err != nil {
 		assert.FailNow(t, "unexpected error while writing test output file: %v, err")
 	}
+
+	t.Run("Render thumbnail", func(t *testing.T) {
+		got, err := g.GenerateCertificate(string(template), cert, link, crt.Variant{Format: crt.FormatPNG, Width: 320})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "image/png", http.DetectContentType(*got))
+		}
+	})
 }
@@ -0,0 +1,164 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/c2fo/vfs/v6"
+	"github.com/c2fo/vfs/v6/backend/s3"
+	"github.com/stretchr/testify/assert"
+	crt "gitlab.com/DzmitryYafremenka/golang-united-school-certs"
+)
+
+// MinIO started by docker-compose.test.yml
+var s3Options = s3.Options{
+	AccessKeyID:     "minioadmin",
+	SecretAccessKey: "minioadmin",
+	Region:          "us-east-1",
+	Endpoint:        "http://localhost:9000",
+	ForcePathStyle:  true,
+}
+
+// Creates empty bucket removed after test, returns client working with it
+func createTestBucket(t *testing.T, bucket string) s3iface.S3API {
+	client, err := s3.NewFileSystem().WithOptions(s3Options).Client()
+	if err != nil {
+		assert.FailNow(t, "failed to create s3 client: %v", err)
+	}
+	if _, err = client.CreateBucket(&awss3.CreateBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		assert.FailNow(t, "failed to create bucket: %v", err)
+	}
+	t.Cleanup(func() {
+		client.ListObjectsV2Pages(&awss3.ListObjectsV2Input{Bucket: aws.String(bucket)},
+			func(page *awss3.ListObjectsV2Output, _ bool) bool {
+				for _, o := range page.Contents {
+					client.DeleteObject(&awss3.DeleteObjectInput{Bucket: aws.String(bucket), Key: o.Key})
+				}
+				return true
+			})
+		client.DeleteBucket(&awss3.DeleteBucketInput{Bucket: aws.String(bucket)})
+	})
+	return client
+}
+
+func createTestS3Storage(t *testing.T, bucket string, diskCapacity int) *crt.VfsStorage {
+	var opts vfs.Options = s3Options
+	s, err := crt.NewVfsStorage(bucket, "/certs/", s3.Scheme, &opts, diskCapacity)
+	if err != nil {
+		assert.FailNow(t, "failed to create storage: %v", err)
+	}
+	return s
+}
+
+// Counts certificate files, index of storage is skipped
+func countObjects(t *testing.T, client s3iface.S3API, bucket string) int {
+	out, err := client.ListObjectsV2(&awss3.ListObjectsV2Input{Bucket: aws.String(bucket), Prefix: aws.String("certs/")})
+	if err != nil {
+		assert.FailNow(t, "failed to list objects: %v", err)
+	}
+	n := 0
+	for _, o := range out.Contents {
+		if strings.HasSuffix(aws.StringValue(o.Key), ".pdf") {
+			n++
+		}
+	}
+	return n
+}
+
+func testCert(id string) []byte {
+	return []byte("%PDF-1.4 " + id)
+}
+
+func Test_S3Storage(t *testing.T) {
+	bucket := fmt.Sprintf("certs-%d", time.Now().UnixNano())
+	client := createTestBucket(t, bucket)
+	timestamp := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+
+	s := createTestS3Storage(t, bucket, 2)
+	assert.NoError(t, s.CheckHealth(context.Background()))
+
+	for _, id := range []string{"06e8469f", "10af7531"} {
+		cert := testCert(id)
+		assert.NoError(t, s.Add(id, timestamp, &cert))
+	}
+	got, err := s.Get("06e8469f", timestamp)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testCert("06e8469f"), *got)
+	}
+	assert.Equal(t, 2, countObjects(t, client, bucket))
+
+	t.Run("Another instance loads files of bucket by index", func(t *testing.T) {
+		assert.NoError(t, s.SaveIndex())
+		s2 := createTestS3Storage(t, bucket, 0)
+		assert.NoError(t, s2.Load())
+		assert.True(t, s2.Contains("06e8469f", timestamp))
+		assert.True(t, s2.Contains("10af7531", timestamp))
+		got, err := s2.Get("10af7531", timestamp)
+		if assert.NoError(t, err) {
+			assert.Equal(t, testCert("10af7531"), *got)
+		}
+	})
+	t.Run("Pre-signed URL downloads file directly", func(t *testing.T) {
+		u, err := s.SignedURL("06e8469f", timestamp, time.Minute)
+		if !assert.NoError(t, err) || !assert.NotEmpty(t, u) {
+			return
+		}
+		resp, err := http.Get(u)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+		assert.Equal(t, testCert("06e8469f"), body)
+	})
+	t.Run("Same content is stored once", func(t *testing.T) {
+		cert := testCert("06e8469f")
+		assert.NoError(t, s.Add("06e8469f", timestamp.Add(time.Hour), &cert))
+		assert.Equal(t, 2, countObjects(t, client, bucket))
+	})
+	t.Run("Evicted file is deleted with configured credentials", func(t *testing.T) {
+		cert := testCert("617dfc5c")
+		assert.NoError(t, s.Add("617dfc5c", timestamp, &cert))
+		assert.Equal(t, 2, countObjects(t, client, bucket))
+	})
+	t.Run("Deleted file is removed from bucket", func(t *testing.T) {
+		s.Delete("617dfc5c", timestamp)
+		assert.False(t, s.Contains("617dfc5c", timestamp))
+		assert.Equal(t, 1, countObjects(t, client, bucket))
+	})
+}
+
+func Test_S3Storage_LoadPages(t *testing.T) {
+	const n = 1100 // more than 1000 keys returned in single page
+	bucket := fmt.Sprintf("certs-pages-%d", time.Now().UnixNano())
+	createTestBucket(t, bucket)
+	timestamp := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+
+	s := createTestS3Storage(t, bucket, 0)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("%08x", i)
+		cert := testCert(id)
+		if err := s.Add(id, timestamp, &cert); err != nil {
+			assert.FailNow(t, "failed to add certificate: %v", err)
+		}
+	}
+	assert.NoError(t, s.SaveIndex())
+
+	s2 := createTestS3Storage(t, bucket, 0)
+	assert.NoError(t, s2.Load())
+	assert.True(t, s2.Contains("00000000", timestamp))
+	assert.True(t, s2.Contains(fmt.Sprintf("%08x", n-1), timestamp))
+}
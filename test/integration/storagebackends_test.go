@@ -0,0 +1,129 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/c2fo/vfs/v6"
+	"github.com/c2fo/vfs/v6/backend/azure"
+	"github.com/c2fo/vfs/v6/backend/gs"
+	"github.com/c2fo/vfs/v6/backend/sftp"
+	"github.com/stretchr/testify/assert"
+	crt "gitlab.com/DzmitryYafremenka/golang-united-school-certs"
+)
+
+// Emulators started by docker-compose.test.yml
+const (
+	gcsEmulatorHost  = "localhost:4443"
+	azuriteEndpoint  = "http://127.0.0.1:10000/devstoreaccount1"
+	azuriteAccount   = "devstoreaccount1"
+	azuriteKey       = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+	sftpVolume       = "certs@localhost:2222"
+	sftpPassword     = "password"
+	sftpUploadFolder = "/upload/"
+)
+
+type newTestStorage func(t *testing.T, diskCapacity int) *crt.VfsStorage
+
+func storageFor(volume string, basePath string, scheme string, o vfs.Options) newTestStorage {
+	return func(t *testing.T, diskCapacity int) *crt.VfsStorage {
+		s, err := crt.NewVfsStorage(volume, basePath, scheme, &o, diskCapacity)
+		if err != nil {
+			assert.FailNow(t, "failed to create storage: %v", err)
+		}
+		return s
+	}
+}
+
+// Runs the same scenario against every backend, observing backend only through storages created by newStorage
+func testStorageBackend(t *testing.T, newStorage newTestStorage) {
+	timestamp := time.Date(2022, 12, 16, 15, 25, 14, 0, time.UTC)
+	cert := func(id string) []byte { return []byte("%PDF-1.4 " + id) }
+
+	s := newStorage(t, 2)
+	assert.NoError(t, s.CheckHealth(context.Background()))
+	for _, id := range []string{"06e8469f", "10af7531"} {
+		c := cert(id)
+		if err := s.Add(id, timestamp, &c); err != nil {
+			assert.FailNow(t, "failed to add certificate: %v", err)
+		}
+	}
+	got, err := s.Get("06e8469f", timestamp)
+	if assert.NoError(t, err) {
+		assert.Equal(t, cert("06e8469f"), *got)
+	}
+
+	t.Run("Another instance loads files by index", func(t *testing.T) {
+		assert.NoError(t, s.SaveIndex())
+		s2 := newStorage(t, 0)
+		assert.NoError(t, s2.Load())
+		got, err := s2.Get("10af7531", timestamp)
+		if assert.NoError(t, err) {
+			assert.Equal(t, cert("10af7531"), *got)
+		}
+	})
+	t.Run("Evicted and deleted files are removed from backend", func(t *testing.T) {
+		// 10af7531 is least recently used
+		c := cert("617dfc5c")
+		assert.NoError(t, s.Add("617dfc5c", timestamp, &c))
+		s.Delete("06e8469f", timestamp)
+		assert.NoError(t, s.SaveIndex())
+
+		s2 := newStorage(t, 0)
+		assert.NoError(t, s2.Load())
+		assert.True(t, s2.Contains("617dfc5c", timestamp))
+		assert.False(t, s2.Contains("10af7531", timestamp))
+		assert.False(t, s2.Contains("06e8469f", timestamp))
+	})
+}
+
+func Test_GCSStorage(t *testing.T) {
+	t.Setenv("STORAGE_EMULATOR_HOST", gcsEmulatorHost)
+	ctx := context.Background()
+	bucket := fmt.Sprintf("certs-%d", time.Now().UnixNano())
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		assert.FailNow(t, "failed to create gcs client: %v", err)
+	}
+	defer client.Close()
+	if err = client.Bucket(bucket).Create(ctx, "test", nil); err != nil {
+		assert.FailNow(t, "failed to create bucket: %v", err)
+	}
+
+	testStorageBackend(t, storageFor(bucket, "/certs/", gs.Scheme,
+		gs.Options{Endpoint: "http://" + gcsEmulatorHost + "/storage/v1/"}))
+}
+
+func Test_AzureStorage(t *testing.T) {
+	container := fmt.Sprintf("certs-%d", time.Now().UnixNano())
+	credential, err := azblob.NewSharedKeyCredential(azuriteAccount, azuriteKey)
+	if err != nil {
+		assert.FailNow(t, "failed to create credential: %v", err)
+	}
+	u, _ := url.Parse(azuriteEndpoint + "/" + container)
+	containerURL := azblob.NewContainerURL(*u, azblob.NewPipeline(credential, azblob.PipelineOptions{}))
+	if _, err = containerURL.Create(context.Background(), nil, azblob.PublicAccessNone); err != nil {
+		assert.FailNow(t, "failed to create container: %v", err)
+	}
+	t.Cleanup(func() { containerURL.Delete(context.Background(), azblob.ContainerAccessConditions{}) })
+
+	testStorageBackend(t, storageFor(container, "/certs/", crt.AzureScheme, crt.AzureOptions{
+		Options:  azure.Options{AccountName: azuriteAccount, AccountKey: azuriteKey},
+		Endpoint: azuriteEndpoint,
+	}))
+}
+
+func Test_SFTPStorage(t *testing.T) {
+	// Host key of container changes with every start
+	t.Setenv("VFS_SFTP_INSECURE_KNOWN_HOSTS", "true")
+	basePath := fmt.Sprintf("%scerts-%d/", sftpUploadFolder, time.Now().UnixNano())
+
+	testStorageBackend(t, storageFor(sftpVolume, basePath, sftp.Scheme, sftp.Options{Password: sftpPassword}))
+}
@@ -0,0 +1,284 @@
+package golangunitedschoolcerts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// Scheme of memory tier in configuration, tier is MemStorage instead of vfs backend
+const MemoryTierScheme = "memory"
+
+// How certificates added to TieredStorage get into tier
+type WritePolicy string
+
+const (
+	// Add returns after certificate is written to tier
+	WriteThrough WritePolicy = "through"
+	// Certificate is queued and written to tier in background, so Add doesn't wait for slow tier
+	WriteBack WritePolicy = "back"
+	// Certificate isn't written to tier by Add, it gets there only by promotion on read
+	WriteAround WritePolicy = "around"
+)
+
+// Tier of TieredStorage
+type StorageTier struct {
+	Name    string
+	Storage Storage
+	// Certificates read from slower tiers are copied to this one
+	PromoteOnRead bool
+	// Empty means WriteThrough
+	Write WritePolicy
+}
+
+// Storage over tiers ordered from fastest to slowest, e.g. memory, local disk and S3.
+// Reads are served by the fastest tier containing certificate.
+type TieredStorage struct {
+	tiers []StorageTier
+	// Queues of write-back tiers, nil for others
+	queues []chan tierOp
+	wg     sync.WaitGroup
+}
+
+// Write or deletion queued for write-back tier
+type tierOp struct {
+	id        string
+	timestamp time.Time
+	// nil for deletion
+	cert *[]byte
+}
+
+var _ Storage = &TieredStorage{}
+
+// Every write-back tier gets queue of queueSize operations, Add blocks while it is full.
+// At least one tier must be write-through, so Add returns only after certificate is stored.
+func NewTieredStorage(tiers []StorageTier, queueSize int) (*TieredStorage, error) {
+	if len(tiers) == 0 {
+		return nil, errors.New("at least one tier is required")
+	}
+	s := &TieredStorage{tiers: make([]StorageTier, len(tiers)), queues: make([]chan tierOp, len(tiers))}
+	writeThrough := false
+	for i, t := range tiers {
+		if t.Storage == nil {
+			return nil, fmt.Errorf("tier %s has no storage", t.Name)
+		}
+		if t.Write == "" {
+			t.Write = WriteThrough
+		}
+		switch t.Write {
+		case WriteThrough:
+			writeThrough = true
+		case WriteBack:
+			if queueSize < 1 {
+				return nil, fmt.Errorf("write-back tier %s requires positive queue size, got %d", t.Name, queueSize)
+			}
+		case WriteAround:
+		default:
+			return nil, fmt.Errorf("unknown write policy of tier %s: %q", t.Name, t.Write)
+		}
+		s.tiers[i] = t
+	}
+	if !writeThrough {
+		return nil, errors.New("at least one tier must be write-through")
+	}
+	for i, t := range s.tiers {
+		if t.Write == WriteBack {
+			s.queues[i] = make(chan tierOp, queueSize)
+			s.wg.Add(1)
+			go s.writeBack(t, s.queues[i])
+		}
+	}
+	return s, nil
+}
+
+func (s *TieredStorage) writeBack(t StorageTier, queue <-chan tierOp) {
+	defer s.wg.Done()
+	for op := range queue {
+		if op.cert == nil {
+			t.Storage.Delete(op.id, op.timestamp)
+			continue
+		}
+		if err := t.Storage.Add(op.id, op.timestamp, op.cert); err != nil {
+			log.Printf("Failed to write back certificate %s to tier %s: %v", op.id, t.Name, err)
+		}
+	}
+}
+
+// Writes certificate to write-through tiers and queues it for write-back ones
+func (s *TieredStorage) Add(id string, timestamp time.Time, cert *[]byte) error {
+	for i, t := range s.tiers {
+		switch t.Write {
+		case WriteThrough:
+			if err := t.Storage.Add(id, timestamp, cert); err != nil {
+				return fmt.Errorf("failed to write certificate to tier %s: %w", t.Name, err)
+			}
+		case WriteBack:
+			s.queues[i] <- tierOp{id, timestamp, cert}
+		}
+	}
+	return nil
+}
+
+// Reads certificate from the fastest tier containing it and copies it to faster tiers promoting on read.
+// Tier failing to read is skipped, so e.g. corrupted file is served by slower tier and replaced by promotion.
+func (s *TieredStorage) Get(id string, timestamp time.Time) (*[]byte, error) {
+	var lastErr error
+	for i, t := range s.tiers {
+		if !t.Storage.Contains(id, timestamp) {
+			continue
+		}
+		cert, err := t.Storage.Get(id, timestamp)
+		if err != nil {
+			log.Printf("Failed to read certificate %s from tier %s: %v", id, t.Name, err)
+			lastErr = err
+			continue
+		}
+		for _, faster := range s.tiers[:i] {
+			if !faster.PromoteOnRead {
+				continue
+			}
+			if err := faster.Storage.Add(id, timestamp, cert); err != nil {
+				log.Printf("Failed to promote certificate %s to tier %s: %v", id, faster.Name, err)
+			}
+		}
+		return cert, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("no certificate file found for such id: %v and timestamp: %v", id, timestamp)
+}
+
+// Streams certificate from the fastest tier containing it. Certificate to be promoted to faster tier
+// is read whole by Get instead, as it is kept whole by that tier anyway.
+func (s *TieredStorage) Open(id string, timestamp time.Time) (io.ReadCloser, error) {
+	var lastErr error
+	for i, t := range s.tiers {
+		if !t.Storage.Contains(id, timestamp) {
+			continue
+		}
+		if s.promotes(i) {
+			cert, err := s.Get(id, timestamp)
+			if err != nil {
+				return nil, err
+			}
+			return bytesFile{bytes.NewReader(*cert)}, nil
+		}
+		r, err := t.Storage.Open(id, timestamp)
+		if err != nil {
+			log.Printf("Failed to open certificate %s in tier %s: %v", id, t.Name, err)
+			lastErr = err
+			continue
+		}
+		return r, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("no certificate file found for such id: %v and timestamp: %v", id, timestamp)
+}
+
+// Reports whether any tier faster than i-th one promotes on read
+func (s *TieredStorage) promotes(i int) bool {
+	for _, t := range s.tiers[:i] {
+		if t.PromoteOnRead {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TieredStorage) Contains(id string, timestamp time.Time) bool {
+	for _, t := range s.tiers {
+		if t.Storage.Contains(id, timestamp) {
+			return true
+		}
+	}
+	return false
+}
+
+// Deletes certificate from every tier, write-back tiers delete it after queued writes
+func (s *TieredStorage) Delete(id string, timestamp time.Time) {
+	for i, t := range s.tiers {
+		if t.Write == WriteBack {
+			s.queues[i] <- tierOp{id: id, timestamp: timestamp}
+			continue
+		}
+		t.Storage.Delete(id, timestamp)
+	}
+}
+
+func (s *TieredStorage) Load() error {
+	for _, t := range s.tiers {
+		if err := t.Storage.Load(); err != nil {
+			return fmt.Errorf("failed to load tier %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *TieredStorage) Checksum(id string, timestamp time.Time) (string, bool) {
+	for _, t := range s.tiers {
+		if sum, ok := t.Storage.Checksum(id, timestamp); ok {
+			return sum, true
+		}
+	}
+	return "", false
+}
+
+// Signs URL in the fastest tier containing certificate and able to sign URLs
+func (s *TieredStorage) SignedURL(id string, timestamp time.Time, ttl time.Duration) (string, error) {
+	for _, t := range s.tiers {
+		signer, ok := t.Storage.(URLSigner)
+		if !ok || !t.Storage.Contains(id, timestamp) {
+			continue
+		}
+		u, err := signer.SignedURL(id, timestamp, ttl)
+		if err != nil {
+			return "", fmt.Errorf("tier %s: %w", t.Name, err)
+		}
+		if u != "" {
+			return u, nil
+		}
+	}
+	return "", nil
+}
+
+// Checks every tier able to check its health
+func (s *TieredStorage) CheckHealth(ctx context.Context) error {
+	for _, t := range s.tiers {
+		if c, ok := t.Storage.(HealthChecker); ok {
+			if err := c.CheckHealth(ctx); err != nil {
+				return fmt.Errorf("tier %s: %w", t.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Saves index of every tier keeping one, see VfsStorage.SaveIndex
+func (s *TieredStorage) SaveIndex() error {
+	for _, t := range s.tiers {
+		if i, ok := t.Storage.(IndexSaver); ok {
+			if err := i.SaveIndex(); err != nil {
+				return fmt.Errorf("tier %s: %w", t.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Waits until queued writes are done. Storage must not be used after Close.
+func (s *TieredStorage) Close() {
+	for _, q := range s.queues {
+		if q != nil {
+			close(q)
+		}
+	}
+	s.wg.Wait()
+}
@@ -0,0 +1,281 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/c2fo/vfs/v6/backend/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func createTestTieredStorage(t *testing.T, tiers ...StorageTier) *TieredStorage {
+	s, err := NewTieredStorage(tiers, 10)
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating tiered storage: %v", err)
+	}
+	return s
+}
+
+func Test_NewTieredStorage(t *testing.T) {
+	m := createTestMemStorage(t, 0)
+	tests := map[string]struct {
+		tiers     []StorageTier
+		queueSize int
+		expErr    string
+	}{
+		"No tiers":        {nil, 1, "at least one tier"},
+		"Missing storage": {[]StorageTier{{Name: "disk"}}, 1, "tier disk has no storage"},
+		"Unknown policy":  {[]StorageTier{{Name: "disk", Storage: m, Write: "sometimes"}}, 1, "unknown write policy"},
+		"No write-through tier": {[]StorageTier{
+			{Name: "memory", Storage: m, Write: WriteAround},
+			{Name: "disk", Storage: m, Write: WriteBack},
+		}, 1, "must be write-through"},
+		"Write-back without queue": {[]StorageTier{
+			{Name: "memory", Storage: m},
+			{Name: "disk", Storage: m, Write: WriteBack},
+		}, 0, "positive queue size"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTieredStorage(tt.tiers, tt.queueSize)
+			assert.ErrorContains(t, err, tt.expErr)
+		})
+	}
+	t.Run("Empty policy means write-through", func(t *testing.T) {
+		s, err := NewTieredStorage([]StorageTier{{Name: "memory", Storage: m}}, 0)
+		if assert.NoError(t, err) {
+			assert.Equal(t, WriteThrough, s.tiers[0].Write)
+		}
+	})
+}
+
+func Test_TieredStorage_Add(t *testing.T) {
+	memory := createTestMemStorage(t, 0)
+	disk := createTestMemStorage(t, 0)
+	archive := createTestMemStorage(t, 0)
+	s := createTestTieredStorage(t,
+		StorageTier{Name: "memory", Storage: memory, Write: WriteAround},
+		StorageTier{Name: "disk", Storage: disk, Write: WriteThrough},
+		StorageTier{Name: "archive", Storage: archive, Write: WriteBack},
+	)
+	now := time.Now()
+	cert := []byte{1, 1, 1, 1}
+
+	assert.NoError(t, s.Add("id", now, &cert))
+	assert.NoError(t, s.Add("deleted", now, &cert))
+	s.Delete("deleted", now)
+	assert.False(t, memory.Contains("id", now))
+	assert.True(t, disk.Contains("id", now))
+	assert.True(t, s.Contains("id", now))
+
+	// Queued writes and deletions are done in order
+	s.Close()
+	assert.True(t, archive.Contains("id", now))
+	assert.False(t, archive.Contains("deleted", now))
+
+	t.Run("Write-through tier returns error", func(t *testing.T) {
+		sMock := NewMockStorage(t)
+		sMock.EXPECT().Add("id", now, &cert).Return(fmt.Errorf("Add error"))
+		s := createTestTieredStorage(t, StorageTier{Name: "disk", Storage: sMock})
+		assert.ErrorContains(t, s.Add("id", now, &cert), "Add error")
+	})
+}
+
+func Test_TieredStorage_Get(t *testing.T) {
+	now := time.Now()
+	cert := []byte{1, 1, 1, 1}
+
+	t.Run("Certificate is promoted to faster tiers promoting on read", func(t *testing.T) {
+		memory := createTestMemStorage(t, 0)
+		local := createTestMemStorage(t, 0)
+		disk := createTestStorage(t, mem.Scheme, "/testtiered/")
+		s := createTestTieredStorage(t,
+			StorageTier{Name: "memory", Storage: memory, PromoteOnRead: true, Write: WriteAround},
+			StorageTier{Name: "local", Storage: local, Write: WriteAround},
+			StorageTier{Name: "disk", Storage: disk},
+		)
+		assert.NoError(t, s.Add("id", now, &cert))
+		assert.False(t, memory.Contains("id", now))
+
+		got, err := s.Get("id", now)
+		assert.NoError(t, err)
+		assert.Equal(t, cert, *got)
+		assert.True(t, memory.Contains("id", now))
+		assert.False(t, local.Contains("id", now))
+	})
+	t.Run("Failing tier is skipped and healed by promotion", func(t *testing.T) {
+		sMock := NewMockStorage(t)
+		sMock.EXPECT().Contains("id", now).Return(true)
+		sMock.EXPECT().Get("id", now).Return(nil, fmt.Errorf("checksum mismatch"))
+		sMock.EXPECT().Add("id", now, mock.Anything).Return(nil)
+		disk := createTestMemStorage(t, 0)
+		assert.NoError(t, disk.Add("id", now, &cert))
+		s := createTestTieredStorage(t,
+			StorageTier{Name: "local", Storage: sMock, PromoteOnRead: true},
+			StorageTier{Name: "disk", Storage: disk},
+		)
+
+		got, err := s.Get("id", now)
+		assert.NoError(t, err)
+		assert.Equal(t, cert, *got)
+	})
+	t.Run("Error of the last failing tier is returned", func(t *testing.T) {
+		sMock := NewMockStorage(t)
+		sMock.EXPECT().Contains("id", now).Return(true)
+		sMock.EXPECT().Get("id", now).Return(nil, fmt.Errorf("read error"))
+		s := createTestTieredStorage(t,
+			StorageTier{Name: "memory", Storage: createTestMemStorage(t, 0)},
+			StorageTier{Name: "disk", Storage: sMock},
+		)
+		_, err := s.Get("id", now)
+		assert.ErrorContains(t, err, "read error")
+	})
+	t.Run("No tier contains certificate", func(t *testing.T) {
+		s := createTestTieredStorage(t, StorageTier{Name: "memory", Storage: createTestMemStorage(t, 0)})
+		got, err := s.Get("id", now)
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, "no certificate file found")
+	})
+}
+
+func Test_TieredStorage_Open(t *testing.T) {
+	now := time.Now()
+	cert := []byte{1, 1, 1, 1}
+	read := func(t *testing.T, r io.ReadCloser) []byte {
+		defer r.Close()
+		got, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		return got
+	}
+
+	t.Run("Certificate is streamed from the fastest tier", func(t *testing.T) {
+		sMock := NewMockStorage(t)
+		disk := createTestStorage(t, mem.Scheme, "/testtieredopen/")
+		s := createTestTieredStorage(t,
+			StorageTier{Name: "memory", Storage: sMock, Write: WriteAround},
+			StorageTier{Name: "disk", Storage: disk},
+		)
+		sMock.EXPECT().Contains("id", now).Return(false)
+		assert.NoError(t, s.Add("id", now, &cert))
+
+		r, err := s.Open("id", now)
+		if assert.NoError(t, err) {
+			assert.IsType(t, &verifiedFile{}, r)
+			assert.Equal(t, cert, read(t, r))
+		}
+	})
+	t.Run("Certificate is read whole to be promoted", func(t *testing.T) {
+		memory := createTestMemStorage(t, 0)
+		disk := createTestMemStorage(t, 0)
+		s := createTestTieredStorage(t,
+			StorageTier{Name: "memory", Storage: memory, PromoteOnRead: true, Write: WriteAround},
+			StorageTier{Name: "disk", Storage: disk},
+		)
+		assert.NoError(t, s.Add("id", now, &cert))
+
+		r, err := s.Open("id", now)
+		if assert.NoError(t, err) {
+			assert.Equal(t, cert, read(t, r))
+		}
+		assert.True(t, memory.Contains("id", now))
+	})
+	t.Run("Failing tier is skipped", func(t *testing.T) {
+		sMock := NewMockStorage(t)
+		sMock.EXPECT().Contains("id", now).Return(true)
+		sMock.EXPECT().Open("id", now).Return(nil, fmt.Errorf("open error"))
+		disk := createTestMemStorage(t, 0)
+		assert.NoError(t, disk.Add("id", now, &cert))
+		s := createTestTieredStorage(t,
+			StorageTier{Name: "local", Storage: sMock},
+			StorageTier{Name: "disk", Storage: disk},
+		)
+
+		r, err := s.Open("id", now)
+		if assert.NoError(t, err) {
+			assert.Equal(t, cert, read(t, r))
+		}
+	})
+	t.Run("No tier contains certificate", func(t *testing.T) {
+		s := createTestTieredStorage(t, StorageTier{Name: "memory", Storage: createTestMemStorage(t, 0)})
+		_, err := s.Open("id", now)
+		assert.ErrorContains(t, err, "no certificate file found")
+	})
+}
+
+func Test_TieredStorage_Checksum(t *testing.T) {
+	now := time.Now()
+	cert := []byte{1, 1, 1, 1}
+	memory := createTestMemStorage(t, 0)
+	disk := createTestMemStorage(t, 0)
+	s := createTestTieredStorage(t,
+		StorageTier{Name: "memory", Storage: memory, Write: WriteAround},
+		StorageTier{Name: "disk", Storage: disk},
+	)
+	_, ok := s.Checksum("id", now)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Add("id", now, &cert))
+	sum, ok := s.Checksum("id", now)
+	assert.True(t, ok)
+	assert.Equal(t, checksum(cert), sum)
+}
+
+func Test_TieredStorage_SignedURL(t *testing.T) {
+	now := time.Now()
+	cert := []byte{1, 1, 1, 1}
+	memory := createTestMemStorage(t, 0)
+	disk := createTestStorage(t, mem.Scheme, "/testtieredsigned/")
+	uMock := NewMockURLSigner(t)
+	archive := struct {
+		*MemStorage
+		*MockURLSigner
+	}{createTestMemStorage(t, 0), uMock}
+	s := createTestTieredStorage(t,
+		StorageTier{Name: "memory", Storage: memory},
+		StorageTier{Name: "disk", Storage: disk},
+		StorageTier{Name: "archive", Storage: archive},
+	)
+	// Tiers not containing certificate aren't asked
+	got, err := s.SignedURL("id", now, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	// Memory can't sign and disk backend signs nothing, so archive is asked
+	assert.NoError(t, s.Add("id", now, &cert))
+	uMock.EXPECT().SignedURL("id", now, time.Minute).Return("https://archive.example.com/id.pdf", nil).Once()
+	got, err = s.SignedURL("id", now, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://archive.example.com/id.pdf", got)
+
+	uMock.EXPECT().SignedURL("id", now, time.Minute).Return("", fmt.Errorf("sign error"))
+	_, err = s.SignedURL("id", now, time.Minute)
+	assert.ErrorContains(t, err, "tier archive: sign error")
+}
+
+func Test_TieredStorage_Load(t *testing.T) {
+	sMock := NewMockStorage(t)
+	sMock.EXPECT().Load().Return(fmt.Errorf("Load error"))
+	s := createTestTieredStorage(t,
+		StorageTier{Name: "memory", Storage: createTestMemStorage(t, 0)},
+		StorageTier{Name: "disk", Storage: sMock},
+	)
+	err := s.Load()
+	assert.ErrorContains(t, err, "tier disk")
+	assert.ErrorContains(t, err, "Load error")
+}
+
+func Test_TieredStorage_SaveIndex(t *testing.T) {
+	disk := createTestStorage(t, mem.Scheme, "/testtieredindex/")
+	s := createTestTieredStorage(t,
+		StorageTier{Name: "memory", Storage: createTestMemStorage(t, 0), Write: WriteAround},
+		StorageTier{Name: "disk", Storage: disk},
+	)
+	assert.NoError(t, s.CheckHealth(context.Background()))
+	assert.NoError(t, s.Add("id", time.Now(), &[]byte{1}))
+	assert.NoError(t, s.SaveIndex())
+	assert.True(t, fileExists(t, disk, storageIndexName))
+}
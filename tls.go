@@ -0,0 +1,113 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Network of bufconn listeners, peers of in-process gateway report it
+const gatewayNetwork = "bufconn"
+
+// Metadata key carrying subject of client certificate, verified by in-process gateway for REST request
+const ClientSubjectMetadataKey = "x-client-subject"
+
+// Methods of CertsService available without client certificate, rest of them are admin ones
+var publicMethods = map[string]bool{
+	"GetCertificate":         true,
+	"GetCertificateMetadata": true,
+	"GetCertificateLink":     true,
+	"VerifyCertificate":      true,
+	"DownloadCertificate":    true,
+	// Open Badges credentials are verified by anyone
+	"GetCertificateCredential": true,
+	"GetIssuerProfile":         true,
+	"GetIssuerKeys":            true,
+}
+
+func isAdminMethod(fullMethod string) bool {
+	prefix := "/" + api.CertsService_ServiceDesc.ServiceName + "/"
+	return strings.HasPrefix(fullMethod, prefix) && !publicMethods[strings.TrimPrefix(fullMethod, prefix)]
+}
+
+// TLS configuration of listener shared by gRPC and REST: ALPN negotiates h2 for both of them and http/1.1 for REST.
+// With clientCAFile, client certificates are verified when presented, ClientCertUnaryInterceptor requires them for admin methods.
+func NewServerTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+	c := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		c.ClientCAs = x509.NewCertPool()
+		if !c.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+		}
+		c.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return c, nil
+}
+
+// In-memory listener for in-process gateway, so REST calls never leave the process in plaintext.
+// Calls arriving through it are trusted to carry client subject in metadata.
+func NewGatewayListener() *bufconn.Listener {
+	return bufconn.Listen(1024 * 1024)
+}
+
+// Subject of verified client certificate, presented directly or through in-process gateway
+func clientSubject(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) != 0 {
+		return info.State.VerifiedChains[0][0].Subject.String(), true
+	}
+	if p.Addr != nil && p.Addr.Network() == gatewayNetwork {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(ClientSubjectMetadataKey); len(v) == 1 {
+				return v[0], true
+			}
+		}
+	}
+	return "", false
+}
+
+// Rejects admin methods of CertsService called without verified client certificate
+func ClientCertUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isAdminMethod(info.FullMethod) {
+		if _, ok := clientSubject(ctx); !ok {
+			return nil, status.Error(codes.Unauthenticated, "client certificate is required")
+		}
+	}
+	return handler(ctx, req)
+}
+
+// Forwards subject of verified client certificate of REST request to gRPC metadata, used with runtime.WithMetadata
+func ClientCertMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil
+	}
+	return metadata.Pairs(ClientSubjectMetadataKey, r.TLS.VerifiedChains[0][0].Subject.String())
+}
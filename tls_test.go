@@ -0,0 +1,241 @@
+package golangunitedschoolcerts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/DzmitryYafremenka/golang-united-school-certs/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testPKI struct {
+	dir        string
+	caFile     string
+	certFile   string
+	keyFile    string
+	caPool     *x509.CertPool
+	clientCert tls.Certificate
+}
+
+// Issues CA, server certificate for localhost and client certificate, writing CA and server ones into files
+func newTestPKI(t *testing.T) *testPKI {
+	p := &testPKI{dir: t.TempDir()}
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		assert.FailNow(t, "failed to create CA: %v", err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+	p.caPool = x509.NewCertPool()
+	p.caPool.AddCert(ca)
+
+	issue := func(serial int64, cn string, usage x509.ExtKeyUsage) ([]byte, *ecdsa.PrivateKey) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		if err != nil {
+			assert.FailNow(t, "failed to issue certificate: %v", err)
+		}
+		return der, key
+	}
+	writePEM := func(name string, blockType string, b []byte) string {
+		path := filepath.Join(p.dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b}), 0o600); err != nil {
+			assert.FailNow(t, "failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	p.caFile = writePEM("ca.pem", "CERTIFICATE", caDER)
+	serverDER, serverKey := issue(2, "localhost", x509.ExtKeyUsageServerAuth)
+	p.certFile = writePEM("cert.pem", "CERTIFICATE", serverDER)
+	keyDER, _ := x509.MarshalECPrivateKey(serverKey)
+	p.keyFile = writePEM("key.pem", "EC PRIVATE KEY", keyDER)
+
+	clientDER, clientKey := issue(3, "admin", x509.ExtKeyUsageClientAuth)
+	p.clientCert = tls.Certificate{Certificate: [][]byte{clientDER}, PrivateKey: clientKey}
+	return p
+}
+
+func Test_NewServerTLSConfig(t *testing.T) {
+	p := newTestPKI(t)
+	t.Run("Server certificate only", func(t *testing.T) {
+		c, err := NewServerTLSConfig(p.certFile, p.keyFile, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"h2", "http/1.1"}, c.NextProtos)
+		assert.Equal(t, tls.NoClientCert, c.ClientAuth)
+	})
+	t.Run("Client certificates are verified when given", func(t *testing.T) {
+		c, err := NewServerTLSConfig(p.certFile, p.keyFile, p.caFile)
+		assert.NoError(t, err)
+		assert.Equal(t, tls.VerifyClientCertIfGiven, c.ClientAuth)
+		assert.NotNil(t, c.ClientCAs)
+	})
+	t.Run("Key doesn't match certificate", func(t *testing.T) {
+		_, err := NewServerTLSConfig(p.caFile, p.keyFile, "")
+		assert.ErrorContains(t, err, "failed to load key pair")
+	})
+	t.Run("Client CA file has no certificates", func(t *testing.T) {
+		_, err := NewServerTLSConfig(p.certFile, p.keyFile, p.keyFile)
+		assert.ErrorContains(t, err, "no certificates found")
+	})
+}
+
+func Test_isAdminMethod(t *testing.T) {
+	assert.True(t, isAdminMethod("/certs.CertsService/AddTemplate"))
+	assert.True(t, isAdminMethod("/certs.CertsService/ListAuditEvents"))
+	assert.False(t, isAdminMethod("/certs.CertsService/GetCertificate"))
+	assert.False(t, isAdminMethod("/certs.CertsService/VerifyCertificate"))
+	assert.False(t, isAdminMethod("/grpc.health.v1.Health/Check"))
+}
+
+// Serves gRPC and REST on one TLS listener with client CA, REST calls go through in-memory gateway
+func initTestTLSServer(t *testing.T, p *testPKI, rMock *MockRegistry) (addr string, closer func()) {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(ActorUnaryInterceptor, ClientCertUnaryInterceptor))
+	api.RegisterCertsServiceServer(grpcServer, NewCertsServer(rMock, nil, nil, host))
+
+	gatewayLis := NewGatewayListener()
+	go grpcServer.Serve(gatewayLis)
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return gatewayLis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		assert.FailNow(t, "unexpected error while dialing bufconn: %v", err)
+	}
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(ActorHeaderMatcher), runtime.WithMetadata(ClientCertMetadata))
+	if err = api.RegisterCertsServiceHandler(context.Background(), mux, conn); err != nil {
+		assert.FailNow(t, "unexpected error while registering service handler: %v", err)
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.Contains(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+		} else {
+			mux.ServeHTTP(w, r)
+		}
+	}))
+	ts.EnableHTTP2 = true
+	if ts.TLS, err = NewServerTLSConfig(p.certFile, p.keyFile, p.caFile); err != nil {
+		assert.FailNow(t, "unexpected error while configuring TLS: %v", err)
+	}
+	ts.StartTLS()
+	return ts.Listener.Addr().String(), func() {
+		ts.Close()
+		conn.Close()
+		grpcServer.Stop()
+	}
+}
+
+func Test_ClientCertUnaryInterceptor(t *testing.T) {
+	p := newTestPKI(t)
+	rMock := NewMockRegistry(t)
+	addr, closer := initTestTLSServer(t, p, rMock)
+	defer closer()
+
+	dial := func(certs ...tls.Certificate) api.CertsServiceClient {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: p.caPool, ServerName: "localhost", Certificates: certs})
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			assert.FailNow(t, "failed to dial: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return api.NewCertsServiceClient(conn)
+	}
+	httpClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: p.caPool, ServerName: "localhost", Certificates: certs},
+		}}
+	}
+	ctx := context.Background()
+
+	t.Run("Public method over gRPC without client certificate", func(t *testing.T) {
+		rMock.EXPECT().GetCertificate(mock.Anything, "1d28bdcd").Return(&Certificate{Id: "1d28bdcd"}, nil).Once()
+		resp, err := dial().GetCertificateLink(ctx, &api.GetCertificateLinkRequest{Id: "1d28bdcd"})
+		assert.NoError(t, err)
+		assert.Equal(t, host+"certificate/1d28bdcd", resp.GetLink())
+	})
+	t.Run("Admin method over gRPC without client certificate", func(t *testing.T) {
+		_, err := dial().ListTemplates(ctx, &api.ListTemplatesRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+	t.Run("Admin method over gRPC with client certificate", func(t *testing.T) {
+		rMock.EXPECT().ListTemplates(mock.Anything, false).Return([]string{"Name 1"}, nil).Once()
+		resp, err := dial(p.clientCert).ListTemplates(ctx, &api.ListTemplatesRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Name 1"}, resp.GetNames())
+	})
+	t.Run("Admin method over REST with client certificate", func(t *testing.T) {
+		rMock.EXPECT().ListTemplates(mock.Anything, false).Return([]string{"Name 1"}, nil).Once()
+		resp, err := httpClient(p.clientCert).Get("https://" + addr + "/templates")
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	})
+	t.Run("Subject of client certificate is actor, header only names whom it acts for", func(t *testing.T) {
+		rMock.EXPECT().AddTemplate(mock.MatchedBy(func(ctx context.Context) bool {
+			return ActorFromContext(ctx) == "CN=admin" && OnBehalfOfFromContext(ctx) == "mallory"
+		}), "Name", "Content").Return(nil).Twice()
+		mdCtx := metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, "mallory")
+		_, err := dial(p.clientCert).AddTemplate(mdCtx, &api.AddTemplateRequest{Name: "Name", Content: "Content"})
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodPost, "https://"+addr+"/template", strings.NewReader(`{"name": "Name", "content": "Content"}`))
+		req.Header.Set("X-Actor", "mallory")
+		resp, err := httpClient(p.clientCert).Do(req)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	})
+	t.Run("Admin method over REST without client certificate", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "https://"+addr+"/templates", nil)
+		// Subject can't be smuggled through gateway as header
+		req.Header.Set(runtime.MetadataHeaderPrefix+ClientSubjectMetadataKey, "CN=admin")
+		resp, err := httpClient().Do(req)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package golangunitedschoolcerts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Format of rendered certificate
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatPNG  Format = "png"
+	FormatJPEG Format = "jpeg"
+	FormatWEBP Format = "webp"
+)
+
+var formatContentTypes = map[Format]string{
+	FormatPDF:  "application/pdf",
+	FormatPNG:  "image/png",
+	FormatJPEG: "image/jpeg",
+	FormatWEBP: "image/webp",
+}
+
+// Widths of thumbnails in pixels, fixed so clients can't fill storage with arbitrary sizes.
+// The widest one suits Open Graph images.
+var ThumbnailWidths = []int{160, 320, 640, 1200}
+
+// Rendition of certificate, every variant is stored separately
+type Variant struct {
+	Format Format
+	// Width of thumbnail in pixels, zero means full size
+	Width int
+}
+
+// Full size PDF, the variant served unless request asks for another one
+var PDF = Variant{Format: FormatPDF}
+
+// Parses variant of request, empty format means PDF. Thumbnails are images of one of ThumbnailWidths.
+func ParseVariant(format string, width int) (Variant, error) {
+	v := Variant{Format: Format(format), Width: width}
+	if v.Format == "" {
+		v.Format = FormatPDF
+	}
+	if _, ok := formatContentTypes[v.Format]; !ok {
+		return Variant{}, status.Errorf(codes.InvalidArgument, "unknown format %q, must be one of pdf, png, jpeg, webp", format)
+	}
+	if width == 0 {
+		return v, nil
+	}
+	if v.Format == FormatPDF {
+		return Variant{}, status.Error(codes.InvalidArgument, "thumbnails are images, pdf has no width")
+	}
+	for _, w := range ThumbnailWidths {
+		if w == width {
+			return v, nil
+		}
+	}
+	return Variant{}, status.Errorf(codes.InvalidArgument, "width must be one of %v, got %d", ThumbnailWidths, width)
+}
+
+// All variants certificate may be stored in
+func variants() []Variant {
+	var vs []Variant
+	for _, f := range []Format{FormatPDF, FormatPNG, FormatJPEG, FormatWEBP} {
+		vs = append(vs, Variant{Format: f})
+		if f == FormatPDF {
+			continue
+		}
+		for _, w := range ThumbnailWidths {
+			vs = append(vs, Variant{Format: f, Width: w})
+		}
+	}
+	return vs
+}
+
+func (v Variant) ContentType() string {
+	return formatContentTypes[v.Format]
+}
+
+// Format with width of thumbnail, e.g. "png-320"
+func (v Variant) String() string {
+	if v.Width == 0 {
+		return string(v.Format)
+	}
+	return string(v.Format) + "-" + strconv.Itoa(v.Width)
+}
+
+// Key of certificate variant in Storage. PDF is keyed by certificate id alone, as it was before variants.
+func (v Variant) key(id string) string {
+	if v == PDF {
+		return id
+	}
+	return fmt.Sprintf("%s.%s", id, v)
+}
+
+// Format of variant stored under key, see Variant.key
+func keyFormat(key string) Format {
+	if i := strings.LastIndex(key, "."); i != -1 {
+		f, _, _ := strings.Cut(key[i+1:], "-")
+		if _, ok := formatContentTypes[Format(f)]; ok {
+			return Format(f)
+		}
+	}
+	return FormatPDF
+}
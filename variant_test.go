@@ -0,0 +1,60 @@
+package golangunitedschoolcerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ParseVariant(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		width  int
+		exp    Variant
+		expErr string
+	}{
+		"Empty format means PDF": {"", 0, PDF, ""},
+		"Full size image":        {"png", 0, Variant{Format: FormatPNG}, ""},
+		"Thumbnail":              {"webp", 320, Variant{Format: FormatWEBP, Width: 320}, ""},
+		"Unknown format":         {"gif", 0, Variant{}, "unknown format"},
+		"PDF thumbnail":          {"pdf", 320, Variant{}, "pdf has no width"},
+		"Arbitrary width":        {"jpeg", 321, Variant{}, "width must be one of"},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseVariant(tt.format, tt.width)
+			assert.Equal(t, tt.exp, got)
+			if tt.expErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expErr)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func Test_Variant_key(t *testing.T) {
+	assert.Equal(t, "id", PDF.key("id"))
+	assert.Equal(t, "id.png", Variant{Format: FormatPNG}.key("id"))
+	assert.Equal(t, "id.jpeg-640", Variant{Format: FormatJPEG, Width: 640}.key("id"))
+	assert.Equal(t, "image/jpeg", Variant{Format: FormatJPEG, Width: 640}.ContentType())
+
+	// Every variant is stored under its own key
+	keys := map[string]bool{}
+	for _, v := range variants() {
+		keys[v.key("id")] = true
+	}
+	assert.Len(t, keys, 1+3*(1+len(ThumbnailWidths)))
+}
+
+func Test_keyFormat(t *testing.T) {
+	for _, v := range variants() {
+		assert.Equal(t, v.Format, keyFormat(v.key("GUS-2022-7K3F-N2QC-ZB7A-9")), v.String())
+	}
+	assert.Equal(t, FormatPDF, keyFormat("1d28bdcd"))
+	assert.Equal(t, FormatPDF, keyFormat("id.txt"))
+}